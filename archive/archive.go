@@ -0,0 +1,217 @@
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/traefik/structor/file"
+)
+
+const maxFileSize = 1 << 30
+
+// Extract Extracts an archive (tar.gz or zip, file path or URL) into a directory.
+// When all the files of the archive are inside a single root directory (ex: "site/"), this directory is stripped.
+func Extract(source, dst string) error {
+	content, err := getContent(source)
+	if err != nil {
+		return err
+	}
+
+	tmpDir, err := os.MkdirTemp("", "structor-archive")
+	if err != nil {
+		return fmt.Errorf("failed to create temp directory: %w", err)
+	}
+
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	switch getFormat(source) {
+	case "zip":
+		err = extractZip(content, tmpDir)
+	case "tar.gz":
+		err = extractTarGz(content, tmpDir)
+	default:
+		return fmt.Errorf("unsupported archive format: %s", source)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to extract %s: %w", source, err)
+	}
+
+	root, err := getRoot(tmpDir)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(dst), os.ModePerm)
+	if err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	return file.Copy(root, dst)
+}
+
+func getContent(source string) ([]byte, error) {
+	if _, errStat := os.Stat(source); errStat == nil {
+		content, err := os.ReadFile(source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the archive: %w", err)
+		}
+		return content, nil
+	}
+
+	content, err := file.Download(source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download the archive: %w", err)
+	}
+	return content, nil
+}
+
+func getFormat(source string) string {
+	name := source
+	if u, err := url.Parse(source); err == nil && u.Scheme != "" && u.Host != "" {
+		name = u.Path
+	}
+
+	name = strings.ToLower(name)
+
+	switch {
+	case strings.HasSuffix(name, ".zip"):
+		return "zip"
+	case strings.HasSuffix(name, ".tar.gz"), strings.HasSuffix(name, ".tgz"):
+		return "tar.gz"
+	default:
+		return ""
+	}
+}
+
+func extractZip(content []byte, dst string) error {
+	reader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return err
+	}
+
+	for _, f := range reader.File {
+		target, err := getTarget(dst, f.Name)
+		if err != nil {
+			return err
+		}
+
+		if f.FileInfo().IsDir() {
+			if err = os.MkdirAll(target, os.ModePerm); err != nil {
+				return err
+			}
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return err
+		}
+
+		err = writeFile(target, rc)
+		_ = rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func extractTarGz(content []byte, dst string) error {
+	gz, err := gzip.NewReader(bytes.NewReader(content))
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = gz.Close() }()
+
+	reader := tar.NewReader(gz)
+
+	for {
+		header, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		target, err := getTarget(dst, header.Name)
+		if err != nil {
+			return err
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err = os.MkdirAll(target, os.ModePerm); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err = writeFile(target, reader); err != nil {
+				return err
+			}
+		default:
+			// the links and the special files are ignored.
+		}
+	}
+}
+
+// getTarget gets the path of a file of the archive inside the destination, and rejects the paths outside the destination.
+func getTarget(dst, name string) (string, error) {
+	target := filepath.Join(dst, filepath.FromSlash(name))
+
+	if target != dst && !strings.HasPrefix(target, dst+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid file path in the archive: %s", name)
+	}
+
+	return target, nil
+}
+
+func writeFile(target string, r io.Reader) error {
+	err := os.MkdirAll(filepath.Dir(target), os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(target)
+	if err != nil {
+		return err
+	}
+
+	// the size of the files is limited to prevent decompression bombs.
+	n, err := io.CopyN(f, r, maxFileSize+1)
+	if err != nil && !errors.Is(err, io.EOF) {
+		_ = f.Close()
+		return err
+	}
+
+	if n > maxFileSize {
+		_ = f.Close()
+		return fmt.Errorf("file too large in the archive: %s", target)
+	}
+
+	return f.Close()
+}
+
+// getRoot gets the root directory of the extracted files.
+func getRoot(dir string) (string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+
+	if len(entries) == 1 && entries[0].IsDir() {
+		return filepath.Join(dir, entries[0].Name()), nil
+	}
+
+	return dir, nil
+}
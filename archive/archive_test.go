@@ -0,0 +1,90 @@
+package archive
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtract(t *testing.T) {
+	server := httptest.NewServer(http.FileServer(http.Dir("./fixtures")))
+	t.Cleanup(server.Close)
+
+	testCases := []struct {
+		desc   string
+		source string
+	}{
+		{
+			desc:   "tar.gz with a root directory",
+			source: filepath.Join(".", "fixtures", "site.tar.gz"),
+		},
+		{
+			desc:   "zip",
+			source: filepath.Join(".", "fixtures", "site.zip"),
+		},
+		{
+			desc:   "URL",
+			source: server.URL + "/site.tar.gz?download=true",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			dir, err := os.MkdirTemp("", "structor-test")
+			require.NoError(t, err)
+			defer func() { _ = os.RemoveAll(dir) }()
+
+			dst := filepath.Join(dir, "site", "v1.6")
+
+			err = Extract(test.source, dst)
+			require.NoError(t, err)
+
+			assert.FileExists(t, filepath.Join(dst, "index.html"))
+			assert.FileExists(t, filepath.Join(dst, "sub", "page.html"))
+		})
+	}
+}
+
+func TestExtract_error(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		source string
+	}{
+		{
+			desc:   "file outside the destination",
+			source: filepath.Join(".", "fixtures", "zip-slip.zip"),
+		},
+		{
+			desc:   "unsupported format",
+			source: filepath.Join("..", "readme.md"),
+		},
+		{
+			desc:   "missing file",
+			source: filepath.Join(".", "fixtures", "missing.zip"),
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			dir, err := os.MkdirTemp("", "structor-test")
+			require.NoError(t, err)
+			defer func() { _ = os.RemoveAll(dir) }()
+
+			err = Extract(test.source, filepath.Join(dir, "v1.6"))
+			assert.Error(t, err)
+
+			assert.NoFileExists(t, filepath.Join(dir, "evil.html"))
+		})
+	}
+}
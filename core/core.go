@@ -10,6 +10,7 @@ import (
 
 	"github.com/ldez/go-git-cmd-wrapper/git"
 	"github.com/ldez/go-git-cmd-wrapper/worktree"
+	"github.com/traefik/structor/archive"
 	"github.com/traefik/structor/docker"
 	"github.com/traefik/structor/file"
 	"github.com/traefik/structor/gh"
@@ -65,54 +66,174 @@ func process(workDir string, config *types.Configuration) error {
 
 	log.Printf("Latest tag: %s", latestTagName)
 
-	branches, err := getBranches(config.ExperimentalBranchName, config.ExcludedBranches, config.Debug)
+	branches, removed, err := getRetainedBranches(config, latestTagName)
 	if err != nil {
-		return fmt.Errorf("failed to get branches: %w", err)
+		return err
 	}
 
-	siteDir, err := createSiteDirectory()
+	baseVersionsInfo, err := getBaseVersionsInformation(config, latestTagName, branches)
 	if err != nil {
-		return fmt.Errorf("failed to create site directory: %w", err)
+		return err
 	}
 
-	for _, branchRef := range branches {
-		versionName := strings.Replace(branchRef, baseRemote, "", 1)
-		log.Printf("Generating doc for version %s", versionName)
+	buildPlan, err := plan(config, branches)
+	if err != nil {
+		return err
+	}
 
-		versionCurrentPath := filepath.Join(workDir, versionName)
+	siteDir, err := prepareSiteDirectory(config, buildPlan)
+	if err != nil {
+		return fmt.Errorf("failed to create site directory: %w", err)
+	}
 
-		err := repository.CreateWorkTree(versionCurrentPath, branchRef, config.Debug)
+	for _, branchRef := range buildPlan.build {
+		err = buildVersion(workDir, siteDir, branchRef, branches, baseVersionsInfo, fallbackDockerfile, menuContent, requirementsContent, config)
 		if err != nil {
-			return fmt.Errorf("failed to create worktree: %w", err)
+			return err
 		}
+	}
 
-		versionDocsRoot, err := getDocumentationRoot(versionCurrentPath)
-		if err != nil {
-			return fmt.Errorf("failed to get documentation path: %w", err)
+	err = importArchives(baseVersionsInfo, branches, menuContent, siteDir)
+	if err != nil {
+		return err
+	}
+
+	err = removeRetiredVersions(config, siteDir, branches, removed)
+	if err != nil {
+		return err
+	}
+
+	err = updateInventory(baseVersionsInfo, branches, buildPlan, menuContent, siteDir, config)
+	if err != nil {
+		return err
+	}
+
+	return finalizeSite(config, siteDir, branches, removed)
+}
+
+// finalizeSite writes the files computed from all the versions of the site: redirections, server configurations, and 404 page.
+func finalizeSite(config *types.Configuration, siteDir string, branches, removed []string) error {
+	err := writeRedirects(config, siteDir)
+	if err != nil {
+		return err
+	}
+
+	err = writeTraefikConfiguration(config, siteDir, branches, removed)
+	if err != nil {
+		return err
+	}
+
+	return writeNotFound(config, siteDir)
+}
+
+// buildVersion builds the documentation of a version, and copies it into the output directory.
+func buildVersion(workDir, siteDir, branchRef string, branches []string, baseVersionsInfo types.VersionsInformation,
+	fallbackDockerfile docker.DockerfileInformation, menuContent menu.Content, requirementsContent []byte, config *types.Configuration,
+) error {
+	versionName := strings.Replace(branchRef, baseRemote, "", 1)
+	log.Printf("Generating doc for version %s", versionName)
+
+	versionDocsRoot, err := checkoutVersion(filepath.Join(workDir, versionName), branchRef, config.Debug)
+	if err != nil {
+		return err
+	}
+
+	versionsInfo := baseVersionsInfo
+	versionsInfo.Current = versionName
+	versionsInfo.CurrentPath = versionDocsRoot
+
+	fallbackDockerfile.Path = filepath.Join(versionsInfo.CurrentPath, fallbackDockerfile.Name)
+
+	err = buildDocumentation(branches, versionsInfo, fallbackDockerfile, menuContent, requirementsContent, config)
+	if err != nil {
+		return fmt.Errorf("failed to build documentation: %w", err)
+	}
+
+	err = copyVersionSiteToOutputSite(versionsInfo, siteDir)
+	if err != nil {
+		return fmt.Errorf("failed to copy site directory: %w", err)
+	}
+
+	return nil
+}
+
+// getBaseVersionsInformation gets the information shared by all the versions.
+func getBaseVersionsInformation(config *types.Configuration, latestTagName string, branches []string) (types.VersionsInformation, error) {
+	commits, err := getCommits(branches, config.Debug)
+	if err != nil {
+		return types.VersionsInformation{}, fmt.Errorf("failed to get commits: %w", err)
+	}
+
+	tags, err := repository.ListTags(config.Debug)
+	if err != nil {
+		return types.VersionsInformation{}, fmt.Errorf("failed to get tags: %w", err)
+	}
+
+	return types.VersionsInformation{
+		Owner:        config.Owner,
+		Repository:   config.RepositoryName,
+		Latest:       latestTagName,
+		Experimental: config.ExperimentalBranchName,
+		Commits:      commits,
+		Tags:         tags,
+		SiteURL:      config.SiteURL,
+		BasePath:     config.BasePath,
+		Settings:     config.Settings,
+	}, nil
+}
+
+// checkoutVersion creates the worktree of a version, and returns the path of its documentation root.
+func checkoutVersion(versionCurrentPath, branchRef string, debug bool) (string, error) {
+	err := repository.CreateWorkTree(versionCurrentPath, branchRef, debug)
+	if err != nil {
+		return "", fmt.Errorf("failed to create worktree: %w", err)
+	}
+
+	versionDocsRoot, err := getDocumentationRoot(versionCurrentPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to get documentation path: %w", err)
+	}
+
+	err = requirements.Check(versionDocsRoot)
+	if err != nil {
+		return "", fmt.Errorf("failed to check requirements: %w", err)
+	}
+
+	return versionDocsRoot, nil
+}
+
+// importArchives extracts the pre-built archives of the versions into the output directory.
+func importArchives(versionsInfo types.VersionsInformation, branches []string, menuContent menu.Content, siteDir string) error {
+	for _, extra := range versionsInfo.Settings.GetExtra() {
+		if extra.Archive == "" {
+			continue
 		}
 
-		err = requirements.Check(versionDocsRoot)
+		log.Printf("Importing archive for version %s", extra.Name)
+
+		archiveDir := filepath.Join(siteDir, filepath.FromSlash(extra.GetPath()))
+
+		// the files of a previous import of the archive are removed.
+		err := os.RemoveAll(archiveDir)
 		if err != nil {
-			return fmt.Errorf("failed to check requirements: %w", err)
+			return fmt.Errorf("failed to remove the previous import of %s: %w", extra.Name, err)
 		}
 
-		versionsInfo := types.VersionsInformation{
-			Current:      versionName,
-			Latest:       latestTagName,
-			Experimental: config.ExperimentalBranchName,
-			CurrentPath:  versionDocsRoot,
+		err = archive.Extract(extra.Archive, archiveDir)
+		if err != nil {
+			return fmt.Errorf("failed to import the archive of %s: %w", extra.Name, err)
 		}
 
-		fallbackDockerfile.Path = filepath.Join(versionsInfo.CurrentPath, fallbackDockerfile.Name)
-
-		err = buildDocumentation(branches, versionsInfo, fallbackDockerfile, menuContent, requirementsContent, config)
-		if err != nil {
-			return fmt.Errorf("failed to build documentation: %w", err)
+		if !extra.InjectMenu {
+			continue
 		}
 
-		err = copyVersionSiteToOutputSite(versionsInfo, siteDir)
+		versionsInfo.Current = extra.Name
+		versionsInfo.CurrentPath = archiveDir
+
+		err = menu.BuildArchive(versionsInfo, branches, menuContent, archiveDir)
 		if err != nil {
-			return fmt.Errorf("failed to copy site directory: %w", err)
+			return fmt.Errorf("failed to inject the menu into the archive of %s: %w", extra.Name, err)
 		}
 	}
 
@@ -128,26 +249,42 @@ func getLatestReleaseTagName(owner, repositoryName string) (string, error) {
 	return gh.GetLatestReleaseTagName(owner, repositoryName)
 }
 
-func getBranches(experimentalBranchName string, excludedBranches []string, debug bool) ([]string, error) {
+// getExperimentalBranchNames gets the names of the experimental branch (--exp-branch), and of the experimental branches of the settings.
+func getExperimentalBranchNames(config *types.Configuration) []string {
+	var names []string
+	if config.ExperimentalBranchName != "" {
+		names = append(names, config.ExperimentalBranchName)
+	}
+
+	for _, e := range config.Settings.GetExperimental() {
+		if e.Name != config.ExperimentalBranchName {
+			names = append(names, e.Name)
+		}
+	}
+
+	return names
+}
+
+// getBranches gets the experimental branches, and the branches of the git repository selected by the include and exclude rules.
+func getBranches(config *types.Configuration) ([]string, error) {
 	var branches []string
 
-	if len(experimentalBranchName) > 0 {
-		branches = append(branches, baseRemote+experimentalBranchName)
+	for _, name := range getExperimentalBranchNames(config) {
+		branches = append(branches, baseRemote+name)
 	}
 
-	gitBranches, err := repository.ListBranches(debug)
+	filter, err := newBranchFilter(config)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list branches: %w", err)
+		return nil, err
 	}
 
-	for _, branch := range gitBranches {
-		if containsBranch(excludedBranches, branch) {
-			continue
-		}
-
-		branches = append(branches, branch)
+	gitBranches, err := repository.ListBranches(config.Debug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branches: %w", err)
 	}
 
+	branches = append(branches, filter.Filter(gitBranches, config.Debug)...)
+
 	if len(branches) == 0 {
 		log.Println("[WARN] no branch.")
 	}
@@ -155,14 +292,35 @@ func getBranches(experimentalBranchName string, excludedBranches []string, debug
 	return branches, nil
 }
 
-func containsBranch(branches []string, branch string) bool {
-	for _, v := range branches {
-		if baseRemote+v == branch {
-			return true
+// getCommits gets the commit information of the branches, by version name.
+func getCommits(branches []string, debug bool) (map[string]types.CommitInformation, error) {
+	commits := make(map[string]types.CommitInformation)
+
+	for _, branchRef := range branches {
+		commit, err := repository.GetCommitInformation(branchRef, debug)
+		if err != nil {
+			return nil, err
 		}
+
+		commits[strings.Replace(branchRef, baseRemote, "", 1)] = commit
+	}
+
+	return commits, nil
+}
+
+// newBranchFilter creates the filter of the branches from the flags (--include, --exclude) and the settings.
+func newBranchFilter(config *types.Configuration) (*menu.BranchFilter, error) {
+	include := config.IncludedBranches
+	exclude := config.ExcludedBranches
+	var keepMinors int
+
+	if rules := config.Settings.GetBranches(); rules != nil {
+		include = append(append([]string{}, include...), rules.Include...)
+		exclude = append(append([]string{}, exclude...), rules.Exclude...)
+		keepMinors = rules.KeepMinors
 	}
 
-	return false
+	return menu.NewBranchFilter(include, exclude, keepMinors)
 }
 
 func createSiteDirectory() (string, error) {
@@ -180,6 +338,23 @@ func createSiteDirectory() (string, error) {
 	return siteDir, nil
 }
 
+// getSiteDirectory gets the site directory, without removing its content.
+func getSiteDirectory() (string, error) {
+	currentDir, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	siteDir := filepath.Join(currentDir, "site")
+
+	err = os.MkdirAll(siteDir, os.ModePerm)
+	if err != nil {
+		return "", fmt.Errorf("failed to create site directory: %w", err)
+	}
+
+	return siteDir, nil
+}
+
 func createDirectory(directoryPath string) error {
 	_, err := os.Stat(directoryPath)
 	switch {
@@ -221,9 +396,13 @@ func buildDocumentation(branches []string, versionsInfo types.VersionsInformatio
 		return err
 	}
 
-	err = menu.Build(versionsInfo, branches, menuTemplateContent)
-	if err != nil {
-		return fmt.Errorf("failed to build the menu: %w", err)
+	htmlInjection := config.Menu != nil && config.Menu.Injection == menu.InjectionHTML
+
+	if !htmlInjection {
+		err = menu.Build(versionsInfo, branches, menuTemplateContent)
+		if err != nil {
+			return fmt.Errorf("failed to build the menu: %w", err)
+		}
 	}
 
 	err = requirements.Build(versionsInfo, requirementsContent)
@@ -254,6 +433,13 @@ func buildDocumentation(branches []string, versionsInfo types.VersionsInformatio
 		return fmt.Errorf("failed to run Docker image: %w", err)
 	}
 
+	if htmlInjection {
+		err = menu.BuildHTML(versionsInfo, branches, menuTemplateContent, filepath.Join(versionsInfo.CurrentPath, "site"))
+		if err != nil {
+			return fmt.Errorf("failed to inject the menu: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -269,13 +455,29 @@ func addEditionURI(config *types.Configuration, versionsInfo types.VersionsInfor
 		return fmt.Errorf("failed to read manifest: %w", err)
 	}
 
+	effective, err := manifest.ReadEffective(manifestFile)
+	if err != nil {
+		return fmt.Errorf("failed to resolve manifest: %w", err)
+	}
+
+	manifest.ResolveInherited(manif, effective, "docs_dir")
+
 	docsDirSuffix := getDocsDirSuffix(versionsInfo)
 
-	manifest.AddEditionURI(manif, versionsInfo.Current, docsDirSuffix, true)
+	manifest.AddEditionURI(manif, getEditionVersion(versionsInfo), docsDirSuffix, true)
 
 	return manifest.Write(manifestFile, manif)
 }
 
+// getEditionVersion gets the git reference of the edition links: the reference of the current version (ex: a preview), or its branch.
+func getEditionVersion(versionsInfo types.VersionsInformation) string {
+	if versionsInfo.CurrentRef != "" {
+		return versionsInfo.CurrentRef
+	}
+
+	return versionsInfo.Current
+}
+
 func getDocsDirSuffix(versionsInfo types.VersionsInformation) string {
 	parts := strings.SplitN(versionsInfo.CurrentPath, string(filepath.Separator)+versionsInfo.Current+string(filepath.Separator), 2)
 	if len(parts) <= 1 {
@@ -287,7 +489,7 @@ func getDocsDirSuffix(versionsInfo types.VersionsInformation) string {
 
 // copyVersionSiteToOutputSite adds the generated documentation for the version described in ${versionsInfo} to the output directory.
 // If the current version (branch) name is related to the latest tag, then it's copied at the root of the output directory.
-// Else it is copied under a directory named after the version, at the root of the output directory.
+// Else it is copied under a directory named after the version (or under the path of an experimental branch), at the root of the output directory.
 func copyVersionSiteToOutputSite(versionsInfo types.VersionsInformation, siteDir string) error {
 	currentSiteDir, err := getDocumentationRoot(versionsInfo.CurrentPath)
 	if err != nil {
@@ -295,6 +497,16 @@ func copyVersionSiteToOutputSite(versionsInfo types.VersionsInformation, siteDir
 	}
 
 	outputDir := filepath.Join(siteDir, versionsInfo.Current)
+	if e, ok := versionsInfo.Settings.FindExperimental(versionsInfo.Current); ok {
+		outputDir = filepath.Join(siteDir, filepath.FromSlash(e.GetPath()))
+	}
+
+	// the files of a previous build of the version are removed.
+	err = os.RemoveAll(outputDir)
+	if err != nil {
+		return fmt.Errorf("failed to remove the previous build of %s: %w", versionsInfo.Current, err)
+	}
+
 	if strings.HasPrefix(versionsInfo.Latest, versionsInfo.Current+".") {
 		// Create a permalink for the latest version
 		err := file.Copy(filepath.Join(currentSiteDir, "site"), outputDir)
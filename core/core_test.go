@@ -10,6 +10,8 @@ import (
 	"github.com/ldez/go-git-cmd-wrapper/git"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/traefik/structor/manifest"
+	"github.com/traefik/structor/menu"
 	"github.com/traefik/structor/types"
 )
 
@@ -234,13 +236,13 @@ func Test_getBranches(t *testing.T) {
 	}
 
 	testCases := []struct {
-		desc                   string
-		experimentalBranchName string
-		excludedBranches       []string
-		expected               []string
+		desc     string
+		config   *types.Configuration
+		expected []string
 	}{
 		{
-			desc: "all existing branches",
+			desc:   "all existing branches",
+			config: &types.Configuration{},
 			expected: []string{
 				"origin/v1.3",
 				"origin/v1.2",
@@ -248,27 +250,80 @@ func Test_getBranches(t *testing.T) {
 			},
 		},
 		{
-			desc:                   "add experimental branch",
-			experimentalBranchName: "master",
+			desc:   "add experimental branch",
+			config: &types.Configuration{ExperimentalBranchName: "master"},
+			expected: []string{
+				"origin/master",
+				"origin/v1.3",
+				"origin/v1.2",
+				"origin/v1.1",
+			},
+		},
+		{
+			desc: "add experimental branches",
+			config: &types.Configuration{
+				ExperimentalBranchName: "master",
+				Settings: &types.Settings{
+					Experimental: []types.ExperimentalBranch{{Name: "feature/gateway-api"}},
+				},
+			},
 			expected: []string{
 				"origin/master",
+				"origin/feature/gateway-api",
 				"origin/v1.3",
 				"origin/v1.2",
 				"origin/v1.1",
 			},
 		},
 		{
-			desc:             "exclude one branch",
-			excludedBranches: []string{"v1.1"},
+			desc:   "exclude one branch",
+			config: &types.Configuration{ExcludedBranches: []string{"v1.1"}},
 			expected: []string{
 				"origin/v1.3",
 				"origin/v1.2",
 			},
 		},
 		{
-			desc:             "exclude all branches",
-			excludedBranches: []string{"v1.1", "v1.2", "v1.3"},
-			expected:         nil,
+			desc:     "exclude all branches",
+			config:   &types.Configuration{ExcludedBranches: []string{"v1.1", "v1.2", "v1.3"}},
+			expected: nil,
+		},
+		{
+			desc:   "exclude with a glob",
+			config: &types.Configuration{ExcludedBranches: []string{"v1.[12]"}},
+			expected: []string{
+				"origin/v1.3",
+			},
+		},
+		{
+			desc:   "exclude with a constraint",
+			config: &types.Configuration{ExcludedBranches: []string{"< v1.3"}},
+			expected: []string{
+				"origin/v1.3",
+			},
+		},
+		{
+			desc: "include and exclude from the settings",
+			config: &types.Configuration{
+				IncludedBranches: []string{`/^v1\.[23]$/`},
+				Settings: &types.Settings{
+					Branches: &types.BranchRules{Exclude: []string{"v1.3"}},
+				},
+			},
+			expected: []string{
+				"origin/v1.2",
+			},
+		},
+		{
+			desc: "keep the last minor",
+			config: &types.Configuration{
+				Settings: &types.Settings{
+					Branches: &types.BranchRules{KeepMinors: 1},
+				},
+			},
+			expected: []string{
+				"origin/v1.3",
+			},
 		},
 	}
 
@@ -277,10 +332,79 @@ func Test_getBranches(t *testing.T) {
 		t.Run(test.desc, func(t *testing.T) {
 			t.Parallel()
 
-			branches, err := getBranches(test.experimentalBranchName, test.excludedBranches, true)
+			test.config.Debug = true
+
+			branches, err := getBranches(test.config)
 			require.NoError(t, err)
 
 			assert.Equal(t, test.expected, branches)
 		})
 	}
 }
+
+func Test_importArchives(t *testing.T) {
+	siteDir, err := os.MkdirTemp("", "structor-test")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(siteDir) }()
+
+	// a file of a previous import of the archive.
+	require.NoError(t, os.MkdirAll(filepath.Join(siteDir, "v1.7"), os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(siteDir, "v1.7", "removed.html"), []byte("<html></html>"), 0o644))
+
+	versionsInfo := types.VersionsInformation{
+		Settings: &types.Settings{
+			Extra: []types.ExtraVersion{
+				{Name: "v1.7", Archive: filepath.Join("..", "archive", "fixtures", "site.tar.gz")},
+			},
+		},
+	}
+
+	err = importArchives(versionsInfo, nil, menu.Content{}, siteDir)
+	require.NoError(t, err)
+
+	assert.FileExists(t, filepath.Join(siteDir, "v1.7", "index.html"))
+	assert.NoFileExists(t, filepath.Join(siteDir, "v1.7", "removed.html"))
+}
+
+func Test_addEditionURI(t *testing.T) {
+	testCases := []struct {
+		desc         string
+		versionsInfo types.VersionsInformation
+		expected     string
+	}{
+		{
+			desc:         "branch",
+			versionsInfo: types.VersionsInformation{Current: "v2.9"},
+			expected:     "edit/v2.9/docs/",
+		},
+		{
+			desc:         "preview",
+			versionsInfo: types.VersionsInformation{Current: "pr-1234", CurrentRef: "9a8b7c6d"},
+			expected:     "edit/9a8b7c6d/docs/",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			dir, err := os.MkdirTemp("", "structor-test")
+			require.NoError(t, err)
+			defer func() { _ = os.RemoveAll(dir) }()
+
+			manifestFile := filepath.Join(dir, manifest.FileName)
+			require.NoError(t, os.WriteFile(manifestFile, []byte("site_name: Traefik\n"), 0o644))
+
+			test.versionsInfo.CurrentPath = dir
+
+			err = addEditionURI(&types.Configuration{ForceEditionURI: true}, test.versionsInfo)
+			require.NoError(t, err)
+
+			manif, err := manifest.Read(manifestFile)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, manif["edit_uri"])
+		})
+	}
+}
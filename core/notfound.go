@@ -0,0 +1,29 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/traefik/structor/menu"
+	"github.com/traefik/structor/notfound"
+	"github.com/traefik/structor/types"
+)
+
+// writeNotFound writes the 404 page redirecting to the same page in another version.
+func writeNotFound(config *types.Configuration, siteDir string) error {
+	settings := config.Settings.GetNotFound()
+	if settings == nil {
+		return nil
+	}
+
+	inventory, err := menu.ReadInventory(siteDir)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", menu.InventoryFileName, err)
+	}
+
+	err = notfound.Build(siteDir, menu.GetBasePath(config.SiteURL, config.BasePath), inventory, settings)
+	if err != nil {
+		return fmt.Errorf("failed to build the 404 page: %w", err)
+	}
+
+	return nil
+}
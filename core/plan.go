@@ -0,0 +1,198 @@
+package core
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/traefik/structor/archive"
+	"github.com/traefik/structor/file"
+	"github.com/traefik/structor/menu"
+	"github.com/traefik/structor/repository"
+	"github.com/traefik/structor/types"
+)
+
+// buildPlan the versions to build, and the versions taken from the base site.
+type buildPlan struct {
+	build []string
+	kept  []string
+}
+
+// isPartial checks if some versions are taken from the base site.
+func (p buildPlan) isPartial() bool {
+	return len(p.kept) > 0
+}
+
+// plan selects the versions to build (--only, --since).
+func plan(config *types.Configuration, branches []string) (buildPlan, error) {
+	if len(config.Only) == 0 && config.Since == "" {
+		return buildPlan{build: branches}, nil
+	}
+
+	only := map[string]struct{}{}
+	if len(config.Only) > 0 {
+		filter, err := menu.NewBranchFilter(config.Only, nil, 0)
+		if err != nil {
+			return buildPlan{}, fmt.Errorf("invalid --only: %w", err)
+		}
+
+		for _, branchRef := range filter.Filter(branches, false) {
+			only[branchRef] = struct{}{}
+		}
+
+		if len(only) == 0 {
+			return buildPlan{}, fmt.Errorf("no version matches --only: %s", strings.Join(config.Only, ", "))
+		}
+	}
+
+	var p buildPlan
+	for _, branchRef := range branches {
+		selected, err := isSelected(config, only, branchRef)
+		if err != nil {
+			return buildPlan{}, err
+		}
+
+		if selected {
+			p.build = append(p.build, branchRef)
+		} else {
+			p.kept = append(p.kept, branchRef)
+		}
+	}
+
+	log.Printf("Versions to build: %s", strings.Join(p.build, ", "))
+
+	if config.Debug {
+		log.Printf("Versions taken from the base site: %s", strings.Join(p.kept, ", "))
+	}
+
+	return p, nil
+}
+
+// isSelected checks if a branch must be built: selected by --only, or with commits which are not in the git reference of --since.
+func isSelected(config *types.Configuration, only map[string]struct{}, branchRef string) (bool, error) {
+	if _, ok := only[branchRef]; ok {
+		return true, nil
+	}
+
+	if config.Since == "" {
+		return false, nil
+	}
+
+	return repository.HasCommitsSince(branchRef, config.Since, config.Debug)
+}
+
+// prepareSiteDirectory creates the site directory: empty, or with the content of the base site for a partial build.
+func prepareSiteDirectory(config *types.Configuration, p buildPlan) (string, error) {
+	if !p.isPartial() {
+		return createSiteDirectory()
+	}
+
+	siteDir, err := getSiteDirectory()
+	if err != nil {
+		return "", err
+	}
+
+	if config.BaseSite == "" {
+		return siteDir, nil
+	}
+
+	if baseSite, errAbs := filepath.Abs(config.BaseSite); errAbs == nil && baseSite == siteDir {
+		return siteDir, nil
+	}
+
+	err = createDirectory(siteDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to create site directory: %w", err)
+	}
+
+	log.Printf("Importing base site %s", config.BaseSite)
+
+	if info, errStat := os.Stat(config.BaseSite); errStat == nil && info.IsDir() {
+		err = file.Copy(config.BaseSite, siteDir)
+	} else {
+		err = archive.Extract(config.BaseSite, siteDir)
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("failed to import base site %s: %w", config.BaseSite, err)
+	}
+
+	return siteDir, nil
+}
+
+// updateInventory writes the inventory of the versions,
+// and regenerates the menus of the versions taken from the base site when the versions of the menu changed.
+func updateInventory(versionsInfo types.VersionsInformation, branches []string, p buildPlan, menuContent menu.Content, siteDir string, config *types.Configuration) error {
+	previous, errPrevious := menu.ReadInventory(siteDir)
+
+	inventory, err := menu.BuildInventory(versionsInfo, branches)
+	if err != nil {
+		return err
+	}
+
+	err = inventory.Write(siteDir)
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", menu.InventoryFileName, err)
+	}
+
+	if !p.isPartial() || (errPrevious == nil && inventory.SameMenu(previous)) {
+		return nil
+	}
+
+	log.Println("The versions of the menu changed: regenerating the menus of the versions taken from the base site.")
+
+	injectHTML := config.Menu != nil && config.Menu.Injection == menu.InjectionHTML
+
+	for _, branchRef := range p.kept {
+		versionsInfo.Current = strings.Replace(branchRef, baseRemote, "", 1)
+
+		err = rebuildMenu(versionsInfo, branches, menuContent, inventory, siteDir, injectHTML)
+		if err != nil {
+			return fmt.Errorf("failed to regenerate the menu of %s: %w", versionsInfo.Current, err)
+		}
+	}
+
+	return nil
+}
+
+// rebuildMenu regenerates the menu of a version taken from the base site.
+// The latest version is regenerated into its permalink, then copied to the root of the site:
+// the other versions, in the directories of the root of the site, are not modified.
+func rebuildMenu(versionsInfo types.VersionsInformation, branches []string, menuContent menu.Content, inventory menu.Inventory, siteDir string, injectHTML bool) error {
+	dir, latest := getVersionSiteDir(inventory, versionsInfo.Current, siteDir)
+	if dir == "" {
+		return nil
+	}
+
+	if _, err := os.Stat(dir); err != nil {
+		log.Printf("[WARN] no directory %s for the version %s: its menu is not regenerated.", dir, versionsInfo.Current)
+		return nil
+	}
+
+	err := menu.Rebuild(versionsInfo, branches, menuContent, dir, injectHTML)
+	if err != nil || !latest {
+		return err
+	}
+
+	return file.Copy(dir, siteDir)
+}
+
+// getVersionSiteDir gets the directory of a version in the site, and if the version is the latest version:
+// the latest version is served at the root of the site, and its directory is its permalink.
+func getVersionSiteDir(inventory menu.Inventory, name, siteDir string) (string, bool) {
+	for _, v := range inventory.Versions {
+		if v.Name != name || v.URL != "" {
+			continue
+		}
+
+		if v.Path == "" {
+			return filepath.Join(siteDir, name), true
+		}
+
+		return filepath.Join(siteDir, filepath.FromSlash(v.Path)), false
+	}
+
+	return "", false
+}
@@ -0,0 +1,176 @@
+package core
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ldez/go-git-cmd-wrapper/git"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/structor/menu"
+	"github.com/traefik/structor/types"
+)
+
+func Test_plan(t *testing.T) {
+	git.CmdExecutor = func(name string, debug bool, args ...string) (string, error) {
+		if debug {
+			log.Println(name, strings.Join(args, " "))
+		}
+
+		// the commits of the branches which are not in v2.9.1 (v2.8 has a commit more recent than v2.9.1, but merged into v2.9.1).
+		switch args[len(args)-1] {
+		case "v2.9.1..origin/master":
+			return "12\n", nil
+		case "v2.9.1..origin/v2.9":
+			return "3\n", nil
+		default:
+			return "0\n", nil
+		}
+	}
+
+	branches := []string{"origin/master", "origin/v2.9", "origin/v2.8", "origin/v2.7"}
+
+	testCases := []struct {
+		desc     string
+		config   *types.Configuration
+		expected buildPlan
+	}{
+		{
+			desc:     "all versions",
+			config:   &types.Configuration{},
+			expected: buildPlan{build: branches},
+		},
+		{
+			desc:   "only",
+			config: &types.Configuration{Only: []string{"v2.9", "master"}},
+			expected: buildPlan{
+				build: []string{"origin/master", "origin/v2.9"},
+				kept:  []string{"origin/v2.8", "origin/v2.7"},
+			},
+		},
+		{
+			desc:   "only with a constraint",
+			config: &types.Configuration{Only: []string{">= 2.8"}},
+			expected: buildPlan{
+				build: []string{"origin/v2.9", "origin/v2.8"},
+				kept:  []string{"origin/master", "origin/v2.7"},
+			},
+		},
+		{
+			desc:   "since",
+			config: &types.Configuration{Since: "v2.9.1", Debug: true},
+			expected: buildPlan{
+				build: []string{"origin/master", "origin/v2.9"},
+				kept:  []string{"origin/v2.8", "origin/v2.7"},
+			},
+		},
+		{
+			desc:   "only and since",
+			config: &types.Configuration{Only: []string{"v2.7"}, Since: "v2.9.1"},
+			expected: buildPlan{
+				build: []string{"origin/master", "origin/v2.9", "origin/v2.7"},
+				kept:  []string{"origin/v2.8"},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			p, err := plan(test.config, branches)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, p)
+		})
+	}
+}
+
+func Test_plan_unknownVersion(t *testing.T) {
+	_, err := plan(&types.Configuration{Only: []string{"v1.0"}}, []string{"origin/master", "origin/v2.9"})
+	assert.Error(t, err)
+}
+
+func Test_getVersionSiteDir(t *testing.T) {
+	inventory := menu.Inventory{
+		Versions: []menu.InventoryVersion{
+			{Name: "master", Path: "master"},
+			{Name: "feature/foo", Path: "preview/foo"},
+			{Name: "v2.9", Path: ""},
+			{Name: "v1.7", URL: "https://v1.doc.traefik.io/traefik/"},
+		},
+	}
+
+	testCases := []struct {
+		name           string
+		expected       string
+		expectedLatest bool
+	}{
+		{name: "master", expected: filepath.Join("site", "master")},
+		{name: "feature/foo", expected: filepath.Join("site", "preview", "foo")},
+		{name: "v2.9", expected: filepath.Join("site", "v2.9"), expectedLatest: true},
+		{name: "v1.7", expected: ""},
+		{name: "v1.0", expected: ""},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir, latest := getVersionSiteDir(inventory, test.name, "site")
+
+			assert.Equal(t, test.expected, dir)
+			assert.Equal(t, test.expectedLatest, latest)
+		})
+	}
+}
+
+func Test_rebuildMenu_latest(t *testing.T) {
+	siteDir, err := os.MkdirTemp("", "structor-test")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(siteDir) }()
+
+	page := "<html><head></head><body></body></html>"
+
+	for _, dir := range []string{siteDir, filepath.Join(siteDir, "v2.3"), filepath.Join(siteDir, "v2.2"), filepath.Join(siteDir, "preview", "pr-1")} {
+		require.NoError(t, os.MkdirAll(dir, os.ModePerm))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "index.html"), []byte(page), 0o644))
+	}
+
+	inventory := menu.Inventory{
+		Latest: "v2.3.0",
+		Versions: []menu.InventoryVersion{
+			{Name: "v2.3", Path: ""},
+			{Name: "v2.2", Path: "v2.2"},
+		},
+	}
+
+	versionsInfo := types.VersionsInformation{Current: "v2.3", Latest: "v2.3.0"}
+
+	menuContent := menu.Content{Js: []byte("var current = '{{ .Current }}';")}
+
+	err = rebuildMenu(versionsInfo, []string{"origin/v2.3", "origin/v2.2"}, menuContent, inventory, siteDir, true)
+	require.NoError(t, err)
+
+	for _, file := range []string{"index.html", filepath.Join("v2.3", "index.html")} {
+		content, errRead := os.ReadFile(filepath.Join(siteDir, file))
+		require.NoError(t, errRead)
+
+		assert.Contains(t, string(content), `<script src="theme/js/structor-menu.js"></script>`, file)
+	}
+
+	assert.FileExists(t, filepath.Join(siteDir, "theme", "js", "structor-menu.js"))
+
+	// the other versions are not modified.
+	for _, file := range []string{filepath.Join("v2.2", "index.html"), filepath.Join("preview", "pr-1", "index.html")} {
+		content, errRead := os.ReadFile(filepath.Join(siteDir, file))
+		require.NoError(t, errRead)
+
+		assert.Equal(t, page, string(content), file)
+	}
+}
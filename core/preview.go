@@ -0,0 +1,338 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/traefik/structor/docker"
+	"github.com/traefik/structor/menu"
+	"github.com/traefik/structor/repository"
+	"github.com/traefik/structor/requirements"
+	"github.com/traefik/structor/types"
+)
+
+const (
+	previewDirName   = "preview"
+	previewsFileName = "previews.json"
+)
+
+// previewEntry a preview of the site.
+type previewEntry struct {
+	Name   string    `json:"name"`
+	Ref    string    `json:"ref"`
+	Commit string    `json:"commit"`
+	Date   time.Time `json:"date"`
+}
+
+// IsValidPreviewName checks if a preview name is valid (ex: "pr-1234").
+func IsValidPreviewName(name string) bool {
+	return regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`).MatchString(name)
+}
+
+// Preview builds a git reference into the preview directory of the site ("site/preview/<name>/"),
+// and prunes the previews. The other versions of the site are not modified.
+func Preview(config *types.Configuration, previewConfig *types.PreviewConfiguration) error {
+	siteDir, err := getSiteDirectory()
+	if err != nil {
+		return err
+	}
+
+	if previewConfig.Ref != "" {
+		err = executePreview(siteDir, config, previewConfig)
+		if err != nil {
+			return err
+		}
+	}
+
+	if previewConfig.PrunePreviews {
+		return prunePreviews(siteDir, previewConfig, time.Now())
+	}
+
+	return nil
+}
+
+func executePreview(siteDir string, config *types.Configuration, previewConfig *types.PreviewConfiguration) error {
+	workDir, err := os.MkdirTemp("", "structor")
+	if err != nil {
+		return fmt.Errorf("failed to create temp directory: %w", err)
+	}
+
+	defer func() {
+		if err = cleanAll(workDir, config.Debug); err != nil {
+			log.Println("[WARN] error during cleaning: ", err)
+		}
+	}()
+
+	return buildPreview(workDir, siteDir, config, previewConfig)
+}
+
+func buildPreview(workDir, siteDir string, config *types.Configuration, previewConfig *types.PreviewConfiguration) error {
+	menuContent := menu.GetTemplateContent(config.Menu)
+
+	fallbackDockerfile, err := docker.GetDockerfileFallback(config.DockerfileURL, config.DockerImageName)
+	if err != nil {
+		return fmt.Errorf("failed to get Dockerfile fallback: %w", err)
+	}
+
+	requirementsContent, err := requirements.GetContent(config.RequirementsURL)
+	if err != nil {
+		return fmt.Errorf("failed to get requirements content: %w", err)
+	}
+
+	versionsInfo, branches, err := getPreviewVersionsInformation(siteDir, config, previewConfig)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Generating preview %s of %s", previewConfig.Name, previewConfig.Ref)
+
+	versionsInfo.CurrentPath, err = checkoutVersion(filepath.Join(workDir, previewConfig.Name), previewConfig.Ref, config.Debug)
+	if err != nil {
+		return err
+	}
+
+	fallbackDockerfile.Path = filepath.Join(versionsInfo.CurrentPath, fallbackDockerfile.Name)
+
+	err = buildDocumentation(branches, versionsInfo, fallbackDockerfile, menuContent, requirementsContent, config)
+	if err != nil {
+		return fmt.Errorf("failed to build documentation: %w", err)
+	}
+
+	err = copyVersionSiteToOutputSite(versionsInfo, siteDir)
+	if err != nil {
+		return fmt.Errorf("failed to copy site directory: %w", err)
+	}
+
+	return addPreview(siteDir, previewEntry{
+		Name:   previewConfig.Name,
+		Ref:    previewConfig.Ref,
+		Commit: versionsInfo.Commits[previewConfig.Name].SHA,
+		Date:   time.Now(),
+	})
+}
+
+// getPreviewVersionsInformation gets the versions information of a preview: the versions of the site, and the preview.
+func getPreviewVersionsInformation(siteDir string, config *types.Configuration, previewConfig *types.PreviewConfiguration) (types.VersionsInformation, []string, error) {
+	versionsInfo, branches, err := getSiteVersionsInformation(siteDir, config)
+	if err != nil {
+		return types.VersionsInformation{}, nil, err
+	}
+
+	err = checkPreviewName(previewConfig.Name, versionsInfo, branches)
+	if err != nil {
+		return types.VersionsInformation{}, nil, err
+	}
+
+	commit, err := repository.GetCommitInformation(previewConfig.Ref, config.Debug)
+	if err != nil {
+		return types.VersionsInformation{}, nil, err
+	}
+
+	versionsInfo.Commits[previewConfig.Name] = commit
+	versionsInfo.Current = previewConfig.Name
+	versionsInfo.CurrentRef = previewConfig.Ref
+	versionsInfo.Settings = getPreviewSettings(config.Settings, previewConfig.Name)
+
+	return versionsInfo, append(branches, previewConfig.Name), nil
+}
+
+// checkPreviewName checks that the name of a preview doesn't collide with a version of the site:
+// a branch, an extra version, an experimental branch, or the latest version (ex: "v2.3" for "v2.3.1").
+func checkPreviewName(name string, versionsInfo types.VersionsInformation, branches []string) error {
+	names := append([]string{versionsInfo.Experimental}, branches...)
+
+	for _, exp := range versionsInfo.Settings.GetExperimental() {
+		names = append(names, exp.Name)
+	}
+
+	for _, extra := range versionsInfo.Settings.GetExtra() {
+		names = append(names, extra.Name)
+	}
+
+	for _, n := range names {
+		if name == strings.TrimPrefix(n, baseRemote) {
+			return fmt.Errorf("the preview name %q collides with the version %s", name, n)
+		}
+	}
+
+	if versionsInfo.Latest != "" && (name == versionsInfo.Latest || strings.HasPrefix(versionsInfo.Latest, name+".")) {
+		return fmt.Errorf("the preview name %q collides with the latest version %s", name, versionsInfo.Latest)
+	}
+
+	return nil
+}
+
+// getSiteVersionsInformation gets the versions information of the site from its inventory (versions.json), or from git.
+func getSiteVersionsInformation(siteDir string, config *types.Configuration) (types.VersionsInformation, []string, error) {
+	inventory, err := menu.ReadInventory(siteDir)
+	if errors.Is(err, fs.ErrNotExist) {
+		log.Printf("No %s in %s: the versions are read from git.", menu.InventoryFileName, siteDir)
+
+		latestTagName, errLatest := getLatestReleaseTagName(config.Owner, config.RepositoryName)
+		if errLatest != nil {
+			return types.VersionsInformation{}, nil, fmt.Errorf("failed to get latest release: %w", errLatest)
+		}
+
+		branches, _, errBranches := getRetainedBranches(config, latestTagName)
+		if errBranches != nil {
+			return types.VersionsInformation{}, nil, errBranches
+		}
+
+		versionsInfo, errInfo := getBaseVersionsInformation(config, latestTagName, branches)
+
+		return versionsInfo, branches, errInfo
+	}
+
+	if err != nil {
+		return types.VersionsInformation{}, nil, fmt.Errorf("failed to read %s: %w", menu.InventoryFileName, err)
+	}
+
+	tags, err := repository.ListTags(config.Debug)
+	if err != nil {
+		return types.VersionsInformation{}, nil, fmt.Errorf("failed to get tags: %w", err)
+	}
+
+	return types.VersionsInformation{
+		Owner:        config.Owner,
+		Repository:   config.RepositoryName,
+		Latest:       inventory.Latest,
+		Experimental: inventory.Experimental,
+		Commits:      inventory.GetCommits(),
+		Tags:         tags,
+		SiteURL:      config.SiteURL,
+		BasePath:     config.BasePath,
+		Settings:     config.Settings,
+	}, inventory.GetBranches(), nil
+}
+
+// getPreviewSettings adds the preview to the experimental branches of the settings: the preview is only listed in its own menu.
+func getPreviewSettings(settings *types.Settings, name string) *types.Settings {
+	previewSettings := types.Settings{}
+	if settings != nil {
+		previewSettings = *settings
+	}
+
+	previewSettings.Experimental = append(append([]types.ExperimentalBranch{}, settings.GetExperimental()...), types.ExperimentalBranch{
+		Name:   name,
+		Path:   path.Join(previewDirName, name),
+		Label:  name + " (preview)",
+		Hidden: true,
+	})
+
+	return &previewSettings
+}
+
+// prunePreviews removes the previews older than the max age, or not in the list of the previews to keep.
+// The current preview is never removed.
+func prunePreviews(siteDir string, previewConfig *types.PreviewConfiguration, now time.Time) error {
+	previews, err := readPreviews(siteDir)
+	if err != nil {
+		return err
+	}
+
+	var kept []previewEntry
+	for _, p := range previews {
+		if !isPruned(p, previewConfig, now) {
+			kept = append(kept, p)
+			continue
+		}
+
+		log.Printf("Removing preview %s", p.Name)
+
+		err = os.RemoveAll(filepath.Join(siteDir, previewDirName, p.Name))
+		if err != nil {
+			return fmt.Errorf("failed to remove preview %s: %w", p.Name, err)
+		}
+	}
+
+	return writePreviews(siteDir, kept)
+}
+
+func isPruned(p previewEntry, previewConfig *types.PreviewConfiguration, now time.Time) bool {
+	switch {
+	case p.Name == previewConfig.Name:
+		return false
+	case previewConfig.PruneMaxAge > 0 && now.Sub(p.Date) > previewConfig.PruneMaxAge:
+		return true
+	case len(previewConfig.PruneKeep) > 0:
+		for _, name := range previewConfig.PruneKeep {
+			if name == p.Name {
+				return false
+			}
+		}
+
+		return true
+	default:
+		return false
+	}
+}
+
+// addPreview adds a preview to the index of the previews, or replaces it.
+func addPreview(siteDir string, preview previewEntry) error {
+	previews, err := readPreviews(siteDir)
+	if err != nil {
+		return err
+	}
+
+	var updated []previewEntry
+	for _, p := range previews {
+		if p.Name != preview.Name {
+			updated = append(updated, p)
+		}
+	}
+
+	return writePreviews(siteDir, append(updated, preview))
+}
+
+// readPreviews reads the index of the previews ("site/preview/previews.json").
+func readPreviews(siteDir string) ([]previewEntry, error) {
+	content, err := os.ReadFile(filepath.Join(siteDir, previewDirName, previewsFileName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var previews []previewEntry
+	err = json.Unmarshal(content, &previews)
+	if err != nil {
+		return nil, fmt.Errorf("invalid index of the previews: %w", err)
+	}
+
+	for _, p := range previews {
+		if !IsValidPreviewName(p.Name) {
+			return nil, fmt.Errorf("invalid preview name in the index of the previews: %q", p.Name)
+		}
+	}
+
+	return previews, nil
+}
+
+func writePreviews(siteDir string, previews []previewEntry) error {
+	err := os.MkdirAll(filepath.Join(siteDir, previewDirName), os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	if previews == nil {
+		previews = []previewEntry{}
+	}
+
+	content, err := json.MarshalIndent(previews, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(siteDir, previewDirName, previewsFileName), content, os.ModePerm)
+}
@@ -0,0 +1,185 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/structor/types"
+)
+
+func TestIsValidPreviewName(t *testing.T) {
+	testCases := []struct {
+		name     string
+		expected bool
+	}{
+		{name: "pr-1234", expected: true},
+		{name: "feature_foo.1", expected: true},
+		{name: "", expected: false},
+		{name: "..", expected: false},
+		{name: "foo/bar", expected: false},
+		{name: "-foo", expected: false},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, IsValidPreviewName(test.name))
+		})
+	}
+}
+
+func Test_checkPreviewName(t *testing.T) {
+	versionsInfo := types.VersionsInformation{
+		Latest:       "v2.3.1",
+		Experimental: "master",
+		Settings: &types.Settings{
+			Experimental: []types.ExperimentalBranch{{Name: "feature/foo"}, {Name: "next"}},
+			Extra:        []types.ExtraVersion{{Name: "v1.0"}},
+		},
+	}
+
+	branches := []string{"origin/v2.3", "origin/v2.2", "origin/v1.7"}
+
+	testCases := []struct {
+		desc     string
+		name     string
+		expected bool
+	}{
+		{desc: "valid", name: "pr-1234", expected: true},
+		{desc: "branch", name: "v2.2", expected: false},
+		{desc: "latest branch", name: "v2.3", expected: false},
+		{desc: "experimental branch", name: "master", expected: false},
+		{desc: "configured experimental branch", name: "next", expected: false},
+		{desc: "extra version", name: "v1.0", expected: false},
+		{desc: "latest version", name: "v2.3.1", expected: false},
+		{desc: "latest prefix", name: "v2", expected: false},
+		{desc: "not a latest prefix", name: "v2.3.10", expected: true},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			err := checkPreviewName(test.name, versionsInfo, branches)
+			if test.expected {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func Test_getPreviewSettings(t *testing.T) {
+	settings := &types.Settings{
+		Experimental: []types.ExperimentalBranch{{Name: "master", Label: "Next"}},
+	}
+
+	previewSettings := getPreviewSettings(settings, "pr-1234")
+
+	expected := []types.ExperimentalBranch{
+		{Name: "master", Label: "Next"},
+		{Name: "pr-1234", Path: "preview/pr-1234", Label: "pr-1234 (preview)", Hidden: true},
+	}
+	assert.Equal(t, expected, previewSettings.Experimental)
+
+	// the settings are not modified.
+	assert.Len(t, settings.Experimental, 1)
+}
+
+func Test_prunePreviews(t *testing.T) {
+	now := time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		desc          string
+		previewConfig *types.PreviewConfiguration
+		expected      []string
+	}{
+		{
+			desc:          "max age",
+			previewConfig: &types.PreviewConfiguration{PruneMaxAge: 10 * 24 * time.Hour},
+			expected:      []string{"pr-2", "pr-3"},
+		},
+		{
+			desc:          "keep",
+			previewConfig: &types.PreviewConfiguration{PruneKeep: []string{"pr-1", "pr-3"}},
+			expected:      []string{"pr-1", "pr-3"},
+		},
+		{
+			desc:          "current preview",
+			previewConfig: &types.PreviewConfiguration{Name: "pr-1", PruneKeep: []string{"pr-3"}},
+			expected:      []string{"pr-1", "pr-3"},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			siteDir, err := os.MkdirTemp("", "structor-test")
+			require.NoError(t, err)
+			defer func() { _ = os.RemoveAll(siteDir) }()
+
+			for i, name := range []string{"pr-1", "pr-2", "pr-3"} {
+				err = os.MkdirAll(filepath.Join(siteDir, previewDirName, name), os.ModePerm)
+				require.NoError(t, err)
+
+				err = addPreview(siteDir, previewEntry{Name: name, Ref: name, Date: now.AddDate(0, 0, -20+i*10)})
+				require.NoError(t, err)
+			}
+
+			err = prunePreviews(siteDir, test.previewConfig, now)
+			require.NoError(t, err)
+
+			previews, err := readPreviews(siteDir)
+			require.NoError(t, err)
+
+			var names []string
+			for _, p := range previews {
+				names = append(names, p.Name)
+
+				assert.DirExists(t, filepath.Join(siteDir, previewDirName, p.Name))
+			}
+
+			assert.Equal(t, test.expected, names)
+
+			entries, err := os.ReadDir(filepath.Join(siteDir, previewDirName))
+			require.NoError(t, err)
+			assert.Len(t, entries, len(test.expected)+1)
+		})
+	}
+}
+
+func Test_addPreview(t *testing.T) {
+	siteDir, err := os.MkdirTemp("", "structor-test")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(siteDir) }()
+
+	date := time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	err = addPreview(siteDir, previewEntry{Name: "pr-1", Ref: "aaa", Date: date})
+	require.NoError(t, err)
+
+	err = addPreview(siteDir, previewEntry{Name: "pr-2", Ref: "bbb", Date: date})
+	require.NoError(t, err)
+
+	err = addPreview(siteDir, previewEntry{Name: "pr-1", Ref: "ccc", Date: date})
+	require.NoError(t, err)
+
+	previews, err := readPreviews(siteDir)
+	require.NoError(t, err)
+
+	expected := []previewEntry{
+		{Name: "pr-2", Ref: "bbb", Date: date},
+		{Name: "pr-1", Ref: "ccc", Date: date},
+	}
+	assert.Equal(t, expected, previews)
+}
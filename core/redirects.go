@@ -0,0 +1,52 @@
+package core
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/traefik/structor/menu"
+	"github.com/traefik/structor/redirect"
+	"github.com/traefik/structor/types"
+)
+
+// writeRedirects writes the pages redirecting the moved pages of each version into the site,
+// and the server configurations of the redirections.
+func writeRedirects(config *types.Configuration, siteDir string) error {
+	redirects := config.Settings.GetRedirects()
+	if redirects == nil || len(redirects.Rules) == 0 {
+		return nil
+	}
+
+	inventory, err := menu.ReadInventory(siteDir)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", menu.InventoryFileName, err)
+	}
+
+	redirections, err := inventory.GetRedirections(redirects.Rules)
+	if err != nil {
+		return err
+	}
+
+	err = redirect.WritePages(siteDir, redirections)
+	if err != nil {
+		return fmt.Errorf("failed to write the redirection pages: %w", err)
+	}
+
+	output := siteDir
+	if redirects.Output != "" {
+		output = redirects.Output
+	}
+
+	basePath := menu.GetBasePath(config.SiteURL, config.BasePath)
+
+	for _, format := range redirects.Formats {
+		filename, errWrite := redirect.WriteConfiguration(format, output, basePath, redirections)
+		if errWrite != nil {
+			return errWrite
+		}
+
+		log.Printf("Redirections (%s) written to %s", format, filename)
+	}
+
+	return nil
+}
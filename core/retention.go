@@ -0,0 +1,69 @@
+package core
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/traefik/structor/menu"
+	"github.com/traefik/structor/redirect"
+	"github.com/traefik/structor/types"
+)
+
+// getRetainedBranches gets the branches to build, and the branches removed by the retention policy.
+func getRetainedBranches(config *types.Configuration, latestTagName string) ([]string, []string, error) {
+	branches, err := getBranches(config)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get branches: %w", err)
+	}
+
+	kept, removed, err := menu.Retain(branches, config.Settings.GetRetention(), latestTagName, getExperimentalBranchNames(config), config.Debug)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to apply the retention policy: %w", err)
+	}
+
+	return kept, removed, nil
+}
+
+// removeRetiredVersions removes the versions removed by the retention policy from the site,
+// and replaces their pages with redirections to the nearest kept version:
+// to the same page, or to the root of the kept version when the page doesn't exist in the kept version.
+func removeRetiredVersions(config *types.Configuration, siteDir string, kept, removed []string) error {
+	for _, branchRef := range removed {
+		name := strings.Replace(branchRef, baseRemote, "", 1)
+		versionDir := filepath.Join(siteDir, name)
+
+		// the pages of a previous build of the version (ex: from the base site).
+		pages, err := redirect.ListPages(versionDir)
+		if err != nil {
+			return fmt.Errorf("failed to list the pages of the version %s: %w", name, err)
+		}
+
+		// the files of a previous build of the version are removed.
+		err = os.RemoveAll(versionDir)
+		if err != nil {
+			return fmt.Errorf("failed to remove the version %s: %w", name, err)
+		}
+
+		if !config.Settings.GetRetention().Redirect {
+			continue
+		}
+
+		target := menu.FindNearest(name, kept)
+		if target == "" {
+			log.Printf("[WARN] no version to redirect the version %s to.", name)
+			continue
+		}
+
+		log.Printf("Redirecting version %s to %s", name, target)
+
+		err = redirect.WriteDirStubs(versionDir, filepath.Join(siteDir, target), pages)
+		if err != nil {
+			return fmt.Errorf("failed to redirect the version %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,173 @@
+package core
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/traefik/structor/menu"
+	"github.com/traefik/structor/redirect"
+	"github.com/traefik/structor/traefik"
+	"github.com/traefik/structor/types"
+)
+
+// Names of the Traefik routers and middlewares.
+const (
+	traefikName            = "structor"
+	traefikLatestName      = "structor-latest"
+	traefikStripPrefixName = "structor-strip-prefix"
+	traefikRemovedPrefix   = "structor-removed-"
+)
+
+// writeTraefikConfiguration writes the Traefik dynamic configuration serving the site.
+func writeTraefikConfiguration(config *types.Configuration, siteDir string, kept, removed []string) error {
+	settings := config.Settings.GetTraefik()
+	if settings == nil {
+		return nil
+	}
+
+	inventory, err := menu.ReadInventory(siteDir)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", menu.InventoryFileName, err)
+	}
+
+	basePath := menu.GetBasePath(config.SiteURL, config.BasePath)
+
+	dynamic, err := buildTraefikConfiguration(settings, inventory, basePath, kept, removed, config.Settings.GetRedirects())
+	if err != nil {
+		return err
+	}
+
+	content, err := dynamic.Marshal(settings.GetFormat())
+	if err != nil {
+		return fmt.Errorf("failed to encode the Traefik configuration: %w", err)
+	}
+
+	output := "."
+	if settings.Output != "" {
+		output = settings.Output
+	}
+
+	err = os.MkdirAll(output, os.ModePerm)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", output, err)
+	}
+
+	filename := filepath.Join(output, "traefik-dynamic."+settings.GetFormat())
+
+	err = os.WriteFile(filename, content, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", filename, err)
+	}
+
+	log.Printf("Traefik dynamic configuration written to %s", filename)
+
+	return nil
+}
+
+// buildTraefikConfiguration builds the routers and the middlewares serving the site:
+// the site (with the redirections of the moved pages, and without the base path), "latest/" to the latest version,
+// and the versions removed by the retention policy to their nearest kept version.
+func buildTraefikConfiguration(settings *types.Traefik, inventory menu.Inventory, basePath string, kept, removed []string,
+	redirects *types.Redirects,
+) (traefik.Configuration, error) {
+	service := settings.Service
+	services := map[string]traefik.Service{}
+	if settings.URL != "" {
+		service = traefikName
+		services[traefikName] = traefik.Service{
+			LoadBalancer: &traefik.LoadBalancer{Servers: []traefik.Server{{URL: settings.URL}}},
+		}
+	}
+
+	dynamic := traefik.HTTP{
+		Routers:     map[string]traefik.Router{},
+		Middlewares: map[string]traefik.Middleware{},
+		Services:    services,
+	}
+
+	var middlewares []string
+	if redirects != nil && len(redirects.Rules) > 0 {
+		redirections, err := inventory.GetRedirections(redirects.Rules)
+		if err != nil {
+			return traefik.Configuration{}, err
+		}
+
+		for name, middleware := range redirect.Middlewares(basePath, redirections) {
+			dynamic.Middlewares[name] = middleware
+		}
+
+		// without redirections, there is no chain middleware.
+		if len(redirections) > 0 {
+			middlewares = append(middlewares, redirect.MiddlewaresChainName)
+		}
+	}
+
+	if basePath != "/" {
+		dynamic.Middlewares[traefikStripPrefixName] = traefik.Middleware{
+			StripPrefix: &traefik.StripPrefix{Prefixes: []string{strings.TrimSuffix(basePath, "/")}},
+		}
+
+		middlewares = append(middlewares, traefikStripPrefixName)
+	}
+
+	dynamic.Routers[traefikName] = newTraefikRouter(settings, basePath, middlewares, service)
+
+	if latest := inventory.GetLatestVersion(); latest != "" {
+		// the latest version changes: the redirection is temporary.
+		addTraefikRedirection(dynamic, settings, traefikLatestName, basePath+"latest/", basePath+latest+"/", false, service)
+	}
+
+	for _, branchRef := range removed {
+		name := strings.Replace(branchRef, baseRemote, "", 1)
+
+		target := menu.FindNearest(name, kept)
+		if target == "" {
+			continue
+		}
+
+		addTraefikRedirection(dynamic, settings, traefikRemovedPrefix+toTraefikName(name), basePath+name+"/", basePath+target+"/", true, service)
+	}
+
+	return traefik.Configuration{HTTP: dynamic}, nil
+}
+
+// addTraefikRedirection adds a router, and its middleware, redirecting the pages under a path to the same pages under another path.
+func addTraefikRedirection(dynamic traefik.HTTP, settings *types.Traefik, name, from, to string, permanent bool, service string) {
+	dynamic.Middlewares[name] = traefik.Middleware{
+		RedirectRegex: &traefik.RedirectRegex{
+			Regex:       "^(https?://[^/]+)" + regexp.QuoteMeta(from) + "(.*)$",
+			Replacement: "${1}" + to + "${2}",
+			Permanent:   permanent,
+		},
+	}
+
+	dynamic.Routers[name] = newTraefikRouter(settings, from, []string{name}, service)
+}
+
+func newTraefikRouter(settings *types.Traefik, pathPrefix string, middlewares []string, service string) traefik.Router {
+	rule := fmt.Sprintf("PathPrefix(`%s`)", pathPrefix)
+	if settings.Rule != "" {
+		rule = fmt.Sprintf("(%s) && %s", settings.Rule, rule)
+	}
+
+	return traefik.Router{
+		EntryPoints: settings.EntryPoints,
+		Rule:        rule,
+		Middlewares: middlewares,
+		Service:     service,
+	}
+}
+
+// toTraefikName converts a version name to a part of a Traefik router name (ex: "v2.9" -> "v2-9").
+func toTraefikName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '-' {
+			return r
+		}
+		return '-'
+	}, name)
+}
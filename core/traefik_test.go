@@ -0,0 +1,103 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/structor/menu"
+	"github.com/traefik/structor/traefik"
+	"github.com/traefik/structor/types"
+)
+
+func Test_buildTraefikConfiguration(t *testing.T) {
+	inventory := menu.Inventory{
+		Latest: "v2.9.6",
+		Versions: []menu.InventoryVersion{
+			{Name: "master", Path: "master"},
+			{Name: "v2.9", Path: ""},
+			{Name: "v2.8", Path: "v2.8"},
+		},
+	}
+
+	settings := &types.Traefik{
+		EntryPoints: []string{"websecure"},
+		Rule:        "Host(`doc.traefik.io`)",
+		URL:         "http://docs:8080",
+	}
+
+	redirects := &types.Redirects{
+		Rules: []types.RedirectRule{{From: "basics/", To: "routing/overview/", Versions: "v2.8"}},
+	}
+
+	dynamic, err := buildTraefikConfiguration(settings, inventory, "/traefik/", []string{"origin/master", "origin/v2.9", "origin/v2.8"}, []string{"origin/v1.7"}, redirects)
+	require.NoError(t, err)
+
+	expected := traefik.Configuration{
+		HTTP: traefik.HTTP{
+			Routers: map[string]traefik.Router{
+				"structor": {
+					EntryPoints: []string{"websecure"},
+					Rule:        "(Host(`doc.traefik.io`)) && PathPrefix(`/traefik/`)",
+					Middlewares: []string{"structor-redirects", "structor-strip-prefix"},
+					Service:     "structor",
+				},
+				"structor-latest": {
+					EntryPoints: []string{"websecure"},
+					Rule:        "(Host(`doc.traefik.io`)) && PathPrefix(`/traefik/latest/`)",
+					Middlewares: []string{"structor-latest"},
+					Service:     "structor",
+				},
+				"structor-removed-v1-7": {
+					EntryPoints: []string{"websecure"},
+					Rule:        "(Host(`doc.traefik.io`)) && PathPrefix(`/traefik/v1.7/`)",
+					Middlewares: []string{"structor-removed-v1-7"},
+					Service:     "structor",
+				},
+			},
+			Middlewares: map[string]traefik.Middleware{
+				"structor-redirect-0": {RedirectRegex: &traefik.RedirectRegex{
+					Regex:       `^(https?://[^/]+)/traefik/v2\.8/basics/(?:index\.html)?(\?.*)?$`,
+					Replacement: "${1}/traefik/v2.8/routing/overview/${2}",
+					Permanent:   true,
+				}},
+				"structor-redirects":    {Chain: &traefik.Chain{Middlewares: []string{"structor-redirect-0"}}},
+				"structor-strip-prefix": {StripPrefix: &traefik.StripPrefix{Prefixes: []string{"/traefik"}}},
+				"structor-latest": {RedirectRegex: &traefik.RedirectRegex{
+					Regex:       `^(https?://[^/]+)/traefik/latest/(.*)$`,
+					Replacement: "${1}/traefik/v2.9/${2}",
+				}},
+				"structor-removed-v1-7": {RedirectRegex: &traefik.RedirectRegex{
+					Regex:       `^(https?://[^/]+)/traefik/v1\.7/(.*)$`,
+					Replacement: "${1}/traefik/v2.8/${2}",
+					Permanent:   true,
+				}},
+			},
+			Services: map[string]traefik.Service{
+				"structor": {LoadBalancer: &traefik.LoadBalancer{Servers: []traefik.Server{{URL: "http://docs:8080"}}}},
+			},
+		},
+	}
+
+	assert.Equal(t, expected, dynamic)
+}
+
+func Test_buildTraefikConfiguration_withoutRedirections(t *testing.T) {
+	inventory := menu.Inventory{
+		Latest:   "v2.9.6",
+		Versions: []menu.InventoryVersion{{Name: "v2.9", Path: ""}},
+	}
+
+	settings := &types.Traefik{Service: "docs@docker"}
+
+	// the rule doesn't match any version.
+	redirects := &types.Redirects{
+		Rules: []types.RedirectRule{{From: "basics/", To: "routing/overview/", Versions: "v1.7"}},
+	}
+
+	dynamic, err := buildTraefikConfiguration(settings, inventory, "/", []string{"origin/v2.9"}, nil, redirects)
+	require.NoError(t, err)
+
+	assert.Empty(t, dynamic.HTTP.Routers["structor"].Middlewares)
+	assert.NotContains(t, dynamic.HTTP.Middlewares, "structor-redirects")
+}
@@ -8,6 +8,7 @@ require (
 	github.com/ldez/go-git-cmd-wrapper v0.22.0
 	github.com/spf13/cobra v1.6.1
 	github.com/stretchr/testify v1.8.1
+	golang.org/x/net v0.7.0
 	gopkg.in/yaml.v3 v3.0.1
 )
 
@@ -0,0 +1,79 @@
+package manifest
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+// inheritKey the attribute used by MkDocs to declare a parent manifest.
+// https://www.mkdocs.org/user-guide/configuration/#configuration-inheritance
+const inheritKey = "INHERIT"
+
+// ReadEffective Reads the manifest and resolves its "INHERIT" chain.
+// The result is the merge of all the manifests of the chain, as computed by MkDocs:
+// the maps are merged recursively, and the other values (including lists) of a child replace the values of its parent.
+func ReadEffective(manifestFilePath string) (map[string]interface{}, error) {
+	return readEffective(manifestFilePath, map[string]struct{}{})
+}
+
+func readEffective(manifestFilePath string, visited map[string]struct{}) (map[string]interface{}, error) {
+	absPath, err := filepath.Abs(manifestFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get absolute path of %s: %w", manifestFilePath, err)
+	}
+
+	if _, ok := visited[absPath]; ok {
+		return nil, fmt.Errorf("circular INHERIT detected on %s", absPath)
+	}
+	visited[absPath] = struct{}{}
+
+	manif, err := Read(absPath)
+	if err != nil {
+		return nil, err
+	}
+
+	parentPath, ok := manif[inheritKey].(string)
+	if !ok || parentPath == "" {
+		return manif, nil
+	}
+
+	delete(manif, inheritKey)
+
+	// the parent path is relative to the manifest that declares it.
+	parent, err := readEffective(filepath.Join(filepath.Dir(absPath), parentPath), visited)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the parent manifest of %s: %w", absPath, err)
+	}
+
+	return merge(parent, manif), nil
+}
+
+func merge(parent, child map[string]interface{}) map[string]interface{} {
+	for key, value := range child {
+		childValue, okChild := value.(map[string]interface{})
+		parentValue, okParent := parent[key].(map[string]interface{})
+
+		if okChild && okParent {
+			parent[key] = merge(parentValue, childValue)
+			continue
+		}
+
+		parent[key] = value
+	}
+
+	return parent
+}
+
+// ResolveInherited Copies the effective values of the attributes into the manifest when they are only defined by a parent manifest.
+// It allows to edit those attributes in the manifest without losing the inherited values.
+func ResolveInherited(manif, effective map[string]interface{}, keys ...string) {
+	for _, key := range keys {
+		if _, ok := manif[key]; ok {
+			continue
+		}
+
+		if value, ok := effective[key]; ok {
+			manif[key] = value
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package manifest
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadEffective(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		filename string
+		expected map[string]interface{}
+	}{
+		{
+			desc:     "without INHERIT",
+			filename: filepath.Join("inherit", "base", FileName),
+			expected: map[string]interface{}{
+				"site_name": "Structor",
+				"docs_dir":  "content",
+				"edit_uri":  "edit/master/docs/content/",
+				"theme": map[string]interface{}{
+					"name":     "material",
+					"language": "en",
+					"palette":  map[string]interface{}{"primary": "blue"},
+				},
+				"extra_javascript": []interface{}{"theme/js/extra.js"},
+			},
+		},
+		{
+			desc:     "with INHERIT",
+			filename: filepath.Join("inherit", "fr", FileName),
+			expected: map[string]interface{}{
+				"site_name": "Structor FR",
+				"docs_dir":  "content",
+				"edit_uri":  "edit/master/docs/content/",
+				"theme": map[string]interface{}{
+					"name":     "material",
+					"language": "fr",
+					"palette":  map[string]interface{}{"primary": "blue"},
+				},
+				"extra_javascript": []interface{}{"theme/js/extra.js"},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			content, err := ReadEffective(filepath.Join(".", "fixtures", test.filename))
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, content)
+		})
+	}
+}
+
+func TestReadEffective_circular(t *testing.T) {
+	_, err := ReadEffective(filepath.Join(".", "fixtures", "inherit", "loop", "a.yml"))
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "circular INHERIT detected")
+}
+
+func TestResolveInherited(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		manif     map[string]interface{}
+		effective map[string]interface{}
+		expected  map[string]interface{}
+	}{
+		{
+			desc:  "inherited values",
+			manif: map[string]interface{}{},
+			effective: map[string]interface{}{
+				"docs_dir":         "content",
+				"extra_javascript": []interface{}{"foo.js"},
+			},
+			expected: map[string]interface{}{
+				"docs_dir":         "content",
+				"extra_javascript": []interface{}{"foo.js"},
+			},
+		},
+		{
+			desc: "values defined by the manifest",
+			manif: map[string]interface{}{
+				"extra_javascript": []interface{}{"bar.js"},
+			},
+			effective: map[string]interface{}{
+				"extra_javascript": []interface{}{"bar.js"},
+			},
+			expected: map[string]interface{}{
+				"extra_javascript": []interface{}{"bar.js"},
+			},
+		},
+		{
+			desc:      "no values",
+			manif:     map[string]interface{}{},
+			effective: map[string]interface{}{"site_name": "foo"},
+			expected:  map[string]interface{}{},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			ResolveInherited(test.manif, test.effective, "docs_dir", "extra_javascript")
+
+			assert.Equal(t, test.expected, test.manif)
+		})
+	}
+}
@@ -128,3 +128,15 @@ func AddEditionURI(manif map[string]interface{}, version, docsDirBase string, ov
 
 	manif["edit_uri"] = path.Join("edit", v, docsDirBase, docsDir) + "/"
 }
+
+// SetExtra Sets a value in the "extra" attribute of the manifest file.
+// https://www.mkdocs.org/user-guide/configuration/#extra
+func SetExtra(manif map[string]interface{}, key string, value interface{}) {
+	extra, ok := manif["extra"].(map[string]interface{})
+	if !ok {
+		extra = make(map[string]interface{})
+	}
+
+	extra[key] = value
+	manif["extra"] = extra
+}
@@ -488,3 +488,48 @@ func TestAddEditionURI(t *testing.T) {
 		})
 	}
 }
+
+func TestSetExtra(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		content  map[string]interface{}
+		expected map[string]interface{}
+	}{
+		{
+			desc:    "without extra attribute",
+			content: map[string]interface{}{},
+			expected: map[string]interface{}{
+				"extra": map[string]interface{}{"structor": "foo"},
+			},
+		},
+		{
+			desc: "with existing extra attribute",
+			content: map[string]interface{}{
+				"extra": map[string]interface{}{"version": "v1"},
+			},
+			expected: map[string]interface{}{
+				"extra": map[string]interface{}{"version": "v1", "structor": "foo"},
+			},
+		},
+		{
+			desc: "override",
+			content: map[string]interface{}{
+				"extra": map[string]interface{}{"structor": "bar"},
+			},
+			expected: map[string]interface{}{
+				"extra": map[string]interface{}{"structor": "foo"},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			SetExtra(test.content, "structor", "foo")
+
+			assert.Equal(t, test.expected, test.content)
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package menu
+
+import (
+	"fmt"
+
+	"github.com/traefik/structor/types"
+)
+
+// BuildArchive Builds the menu of a version extracted from a pre-built archive, and injects it into the HTML files of the archive.
+func BuildArchive(versionsInfo types.VersionsInformation, branches []string, menuContent Content, archiveDir string) error {
+	if menuContent.Theme == ThemeAuto {
+		theme := getExtraTheme(versionsInfo.Settings.GetExtra(), versionsInfo.Current)
+		if theme == "" || theme == ThemeAuto || !IsValidTheme(theme) {
+			return fmt.Errorf("a theme is required to inject the menu into the archive of %s", versionsInfo.Current)
+		}
+
+		var err error
+		menuContent, err = menuContent.withTheme(theme)
+		if err != nil {
+			return err
+		}
+	}
+
+	return buildSite(versionsInfo, branches, menuContent, archiveDir, "")
+}
+
+func getExtraTheme(extra []types.ExtraVersion, name string) string {
+	for _, e := range extra {
+		if e.Name == name {
+			return e.Theme
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,82 @@
+package menu
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/structor/types"
+)
+
+func TestBuildArchive(t *testing.T) {
+	dir, err := os.MkdirTemp("", "structor-test")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	archiveDir := filepath.Join(dir, "v1.6")
+
+	err = os.MkdirAll(filepath.Join(archiveDir, "sub"), os.ModePerm)
+	require.NoError(t, err)
+
+	pages := map[string]string{
+		"index.html":    "<html><head><title>v1.6</title></head><body>v1.6</body></html>\n",
+		"sub/page.html": "<html><HEAD></HEAD><body>page</BODY></html>\n",
+		"menu.html":     `<html><head></head><body><script src="theme/js/structor-menu.js"></script></body></html>` + "\n",
+	}
+
+	for name, content := range pages {
+		err = os.WriteFile(filepath.Join(archiveDir, filepath.FromSlash(name)), []byte(content), os.ModePerm)
+		require.NoError(t, err)
+	}
+
+	versionsInfo := types.VersionsInformation{
+		Current:      "v1.6",
+		Latest:       "v2.0.0",
+		Experimental: "master",
+		Settings: &types.Settings{
+			Extra: []types.ExtraVersion{{Name: "v1.6", Archive: "v1.6.tar.gz", InjectMenu: true, Theme: "material"}},
+		},
+	}
+
+	menuContent := Content{Theme: ThemeAuto}
+
+	err = BuildArchive(versionsInfo, []string{"origin/master", "origin/v2.0"}, menuContent, archiveDir)
+	require.NoError(t, err)
+
+	js, err := os.ReadFile(filepath.Join(archiveDir, "theme", "js", menuJsFileName))
+	require.NoError(t, err)
+	assert.Contains(t, string(js), `{name: "v1.6", path: "v1.6", text: "v1.6", state: "", url: "", selected: true},`)
+
+	assert.FileExists(t, filepath.Join(archiveDir, "theme", "css", menuCSSFileName))
+
+	expected := map[string]string{
+		"index.html": `<html><head><title>v1.6</title><link rel="stylesheet" href="theme/css/structor-menu.css">` + "\n" +
+			`</head><body>v1.6<script src="theme/js/structor-menu.js"></script>` + "\n" + "</body></html>\n",
+		"sub/page.html": `<html><HEAD><link rel="stylesheet" href="../theme/css/structor-menu.css">` + "\n" +
+			`</HEAD><body>page<script src="../theme/js/structor-menu.js"></script>` + "\n" + "</BODY></html>\n",
+		"menu.html": `<html><head><link rel="stylesheet" href="theme/css/structor-menu.css">` + "\n" +
+			`</head><body><script src="theme/js/structor-menu.js"></script></body></html>` + "\n",
+	}
+
+	for name, content := range expected {
+		page, err := os.ReadFile(filepath.Join(archiveDir, filepath.FromSlash(name)))
+		require.NoError(t, err)
+
+		assert.Equal(t, content, string(page), name)
+	}
+}
+
+func TestBuildArchive_noTheme(t *testing.T) {
+	versionsInfo := types.VersionsInformation{
+		Current: "v1.6",
+		Latest:  "v2.0.0",
+		Settings: &types.Settings{
+			Extra: []types.ExtraVersion{{Name: "v1.6", Archive: "v1.6.tar.gz", InjectMenu: true}},
+		},
+	}
+
+	err := BuildArchive(versionsInfo, nil, Content{Theme: ThemeAuto}, "")
+	assert.Error(t, err)
+}
@@ -0,0 +1,78 @@
+package menu
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/traefik/structor/file"
+)
+
+// Asset an additional templated file of the menu.
+type Asset struct {
+	Name    string
+	Content []byte
+}
+
+func getAssets(sources []string) ([]Asset, error) {
+	var assets []Asset
+
+	for _, src := range sources {
+		content, err := getAssetContent(src)
+		if err != nil {
+			return nil, err
+		}
+
+		assets = append(assets, Asset{
+			Name:    strings.TrimSuffix(path.Base(src), ".gotmpl"),
+			Content: content,
+		})
+	}
+
+	return assets, nil
+}
+
+func getAssetContent(src string) ([]byte, error) {
+	if _, errStat := os.Stat(src); errStat == nil {
+		content, err := os.ReadFile(src)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read menu asset %s: %w", src, err)
+		}
+		return content, nil
+	}
+
+	content, err := file.Download(src)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download menu asset: %w", err)
+	}
+	return content, nil
+}
+
+// writeAssets renders the assets into the theme directory, inside the docs directory.
+// Returns the paths of the files relative to the docs directory.
+func writeAssets(manifestDocsDir string, menuContent Content, model templateModel) ([]string, error) {
+	var assetFiles []string
+
+	for _, asset := range menuContent.Assets {
+		assetFile, err := writeTemplateFile(manifestDocsDir, getAssetDir(asset.Name), asset.Name, string(asset.Content), model)
+		if err != nil {
+			return nil, fmt.Errorf("failed to write menu asset %s: %w", asset.Name, err)
+		}
+
+		assetFiles = append(assetFiles, assetFile)
+	}
+
+	return assetFiles, nil
+}
+
+func getAssetDir(name string) string {
+	switch path.Ext(name) {
+	case ".js":
+		return "js"
+	case ".css":
+		return "css"
+	default:
+		return "assets"
+	}
+}
@@ -0,0 +1,82 @@
+package menu
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/structor/types"
+)
+
+func Test_getAssets(t *testing.T) {
+	serverURL, teardown := serveFixturesContent()
+	defer teardown()
+
+	assets, err := getAssets([]string{"./fixtures/test-menu.js.gotmpl", serverURL + "/test-menu.css.gotmpl"})
+	require.NoError(t, err)
+
+	expected := []Asset{
+		{Name: "test-menu.js", Content: mustReadFile("./fixtures/test-menu.js.gotmpl")},
+		{Name: "test-menu.css", Content: mustReadFile("./fixtures/server/test-menu.css.gotmpl")},
+	}
+	assert.Equal(t, expected, assets)
+
+	_, err = getAssets([]string{serverURL + "/missing.js.gotmpl"})
+	require.Error(t, err)
+}
+
+func Test_writeAssets(t *testing.T) {
+	dir, err := os.MkdirTemp("", "structor-test")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	menuContent := Content{
+		Assets: []Asset{
+			{Name: "banner.js", Content: []byte(`var current = "{{ .Current }}";`)},
+			{Name: "banner.css", Content: []byte(`/* {{ .Latest }} */`)},
+			{Name: "versions.json", Content: []byte(`{{ len .Versions }}`)},
+		},
+	}
+
+	versionsInfo := types.VersionsInformation{
+		Current: "v1.9",
+		Latest:  "v1.9.6",
+	}
+
+	versions, err := buildVersions(versionsInfo, []string{"origin/v1.9"})
+	require.NoError(t, err)
+
+	assetFiles, err := writeAssets(dir, menuContent, newTemplateModel(versionsInfo, versions, "", ""))
+	require.NoError(t, err)
+
+	expected := []string{
+		filepath.Join("theme", "js", "banner.js"),
+		filepath.Join("theme", "css", "banner.css"),
+		filepath.Join("theme", "assets", "versions.json"),
+	}
+	assert.Equal(t, expected, assetFiles)
+
+	assertFileContent(t, `var current = "v1.9";`, filepath.Join(dir, assetFiles[0]))
+	assertFileContent(t, `/* v1.9.6 */`, filepath.Join(dir, assetFiles[1]))
+	assertFileContent(t, `1`, filepath.Join(dir, assetFiles[2]))
+
+	manif := map[string]interface{}{}
+	editManifestAssets(manif, assetFiles)
+
+	expectedManif := map[string]interface{}{
+		"extra_javascript": []interface{}{filepath.Join("theme", "js", "banner.js")},
+		"extra_css":        []interface{}{filepath.Join("theme", "css", "banner.css")},
+	}
+	assert.Equal(t, expectedManif, manif)
+}
+
+func assertFileContent(t *testing.T, expected, filePath string) {
+	t.Helper()
+
+	content, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, string(content))
+}
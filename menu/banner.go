@@ -0,0 +1,70 @@
+package menu
+
+import (
+	"fmt"
+	"html/template"
+	"strings"
+
+	"github.com/traefik/structor/types"
+)
+
+// bannerLatestURL the placeholder of the URL of the same page in the latest version, replaced by the menu JS at runtime.
+const bannerLatestURL = "__STRUCTOR_LATEST_URL__"
+
+const defaultBannerStyle = "padding: .5em; text-align: center; background-color: #fff3cd; color: #664d03;"
+
+// getDefaultBannerMessages gets the messages used when no message is defined for the state of a version.
+func getDefaultBannerMessages() map[string]string {
+	outdated := `This documentation is for an outdated version ({{ .Version.Text }}). <a href="{{ .LatestURL }}">Go to the latest version.</a>`
+
+	return map[string]string{
+		stateObsolete:        outdated,
+		stateEOL:             outdated,
+		statePreFinalRelease: `This documentation is for a pre-release version ({{ .Version.Text }}). <a href="{{ .LatestURL }}">Go to the latest version.</a>`,
+		stateExperimental:    `This documentation is for the development version. <a href="{{ .LatestURL }}">Go to the latest version.</a>`,
+	}
+}
+
+type bannerData struct {
+	Version   optionVersion
+	LatestURL string
+}
+
+// buildBanner builds the HTML banner of a version, empty if the state of the version has no banner.
+func buildBanner(config *types.Banner, current optionVersion, latestURL string) (string, error) {
+	messages := getDefaultBannerMessages()
+	style := defaultBannerStyle
+
+	if config != nil {
+		for state, message := range config.States {
+			messages[strings.ToUpper(state)] = message
+		}
+
+		if config.Style != "" {
+			style = config.Style
+		}
+	}
+
+	message := messages[current.State]
+	if message == "" {
+		return "", nil
+	}
+
+	tmpl, err := template.New("banner").Parse(message)
+	if err != nil {
+		return "", fmt.Errorf("invalid banner message for %s: %w", current.State, err)
+	}
+
+	b := &strings.Builder{}
+
+	fmt.Fprintf(b, `<div class="structor-banner structor-banner-%s" style="%s">`, strings.ToLower(current.State), template.HTMLEscapeString(style))
+
+	err = tmpl.Execute(b, bannerData{Version: current, LatestURL: latestURL})
+	if err != nil {
+		return "", fmt.Errorf("invalid banner message for %s: %w", current.State, err)
+	}
+
+	b.WriteString("</div>")
+
+	return b.String(), nil
+}
@@ -0,0 +1,66 @@
+package menu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/structor/types"
+)
+
+func Test_buildBanner(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		config   *types.Banner
+		current  optionVersion
+		expected string
+	}{
+		{
+			desc:    "default obsolete banner",
+			current: optionVersion{Name: "v1.5", Text: "v1.5", State: stateObsolete},
+			expected: `<div class="structor-banner structor-banner-obsolete" style="padding: .5em; text-align: center; background-color: #fff3cd; color: #664d03;">` +
+				`This documentation is for an outdated version (v1.5). <a href="../foo/">Go to the latest version.</a></div>`,
+		},
+		{
+			desc:     "no banner for the latest version",
+			current:  optionVersion{Name: "v1.7", Text: "v1.7 Latest", State: stateLatest},
+			expected: "",
+		},
+		{
+			desc: "custom message and style",
+			config: &types.Banner{
+				States: map[string]string{"lts": `{{ .Version.Name }} <b>LTS</b>, <a href="{{ .LatestURL }}">latest</a>`},
+				Style:  "color: red;",
+			},
+			current:  optionVersion{Name: "v1.6", Text: "v1.6", State: stateLTS},
+			expected: `<div class="structor-banner structor-banner-lts" style="color: red;">v1.6 <b>LTS</b>, <a href="../foo/">latest</a></div>`,
+		},
+		{
+			desc: "disabled banner",
+			config: &types.Banner{
+				States: map[string]string{"OBSOLETE": ""},
+			},
+			current:  optionVersion{Name: "v1.5", Text: "v1.5", State: stateObsolete},
+			expected: "",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			banner, err := buildBanner(test.config, test.current, "../foo/")
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, banner)
+		})
+	}
+}
+
+func Test_buildBanner_error(t *testing.T) {
+	config := &types.Banner{States: map[string]string{"OBSOLETE": "{{ .Foo }"}}
+
+	_, err := buildBanner(config, optionVersion{State: stateObsolete}, "")
+	assert.Error(t, err)
+}
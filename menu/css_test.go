@@ -0,0 +1,63 @@
+package menu
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/structor/types"
+)
+
+func Test_writeCSSFile(t *testing.T) {
+	testCases := []struct {
+		desc         string
+		versionsInfo types.VersionsInformation
+		expected     string
+	}{
+		{
+			desc: "obsolete",
+			versionsInfo: types.VersionsInformation{
+				Current: "v1.8",
+				Latest:  "v1.9.6",
+			},
+			expected: `.md-header { background-color: red; }
+`,
+		},
+		{
+			desc: "latest",
+			versionsInfo: types.VersionsInformation{
+				Current: "v1.9",
+				Latest:  "v1.9.6",
+			},
+			expected: `.md-header { background-color: blue; }
+`,
+		},
+	}
+
+	cssTemplate := `.md-header { background-color: {{ if IsObsolete .Versions .Current }}red{{ else }}blue{{ end }}; }
+`
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			dir, err := os.MkdirTemp("", "structor-test")
+			require.NoError(t, err)
+			defer func() { _ = os.RemoveAll(dir) }()
+
+			versions, err := buildVersions(test.versionsInfo, []string{"origin/v1.9", "origin/v1.8"})
+			require.NoError(t, err)
+
+			cssFile, err := writeCSSFile(dir, Content{CSS: []byte(cssTemplate)}, newTemplateModel(test.versionsInfo, versions, "", ""))
+			require.NoError(t, err)
+
+			assert.Equal(t, filepath.Join("theme", "css", menuCSSFileName), cssFile)
+
+			content, err := os.ReadFile(filepath.Join(dir, cssFile))
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, string(content))
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package menu
+
+import "github.com/traefik/structor/types"
+
+// getExperimentalPath gets the path of a version, and checks if the version is an experimental branch.
+func getExperimentalPath(versionsInfo types.VersionsInformation, name string) (string, bool) {
+	if e, ok := versionsInfo.Settings.FindExperimental(name); ok {
+		return e.GetPath(), true
+	}
+
+	return name, name == versionsInfo.Experimental
+}
+
+// hideExperimental removes the hidden experimental branches, except the current version.
+func hideExperimental(versions []optionVersion, experimental []types.ExperimentalBranch) []optionVersion {
+	hidden := map[string]struct{}{}
+	for _, e := range experimental {
+		if e.Hidden {
+			hidden[e.Name] = struct{}{}
+		}
+	}
+
+	if len(hidden) == 0 {
+		return versions
+	}
+
+	var listed []optionVersion
+	for _, v := range versions {
+		if _, ok := hidden[v.Name]; ok && !v.Selected {
+			continue
+		}
+
+		listed = append(listed, v)
+	}
+
+	return listed
+}
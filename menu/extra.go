@@ -0,0 +1,54 @@
+package menu
+
+import (
+	"fmt"
+
+	"github.com/traefik/structor/types"
+)
+
+// getExtraNames gets the names of the versions which are not built from a branch.
+func getExtraNames(extra []types.ExtraVersion) []string {
+	var names []string
+	for _, e := range extra {
+		names = append(names, e.Name)
+	}
+
+	return names
+}
+
+// applyExtra sets the path, the URL, and the state of the versions which are not built from a branch.
+func applyExtra(versions []optionVersion, extra []types.ExtraVersion) error {
+	entries := map[string]types.ExtraVersion{}
+	for _, e := range extra {
+		if e.URL == "" && e.Archive == "" {
+			return fmt.Errorf("invalid extra version %s: an URL or an archive is required", e.Name)
+		}
+
+		entries[e.Name] = e
+	}
+
+	for i, v := range versions {
+		entry, ok := entries[v.Name]
+		if !ok {
+			continue
+		}
+
+		if entry.URL != "" {
+			versions[i].Path = ""
+			versions[i].URL = entry.URL
+		} else {
+			versions[i].Path = entry.GetPath()
+		}
+
+		if entry.State != "" {
+			state, err := parseLifecycleState(entry.State)
+			if err != nil {
+				return fmt.Errorf("invalid extra version %s: %w", entry.Name, err)
+			}
+
+			versions[i].State = state
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,34 @@
+package menu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/traefik/structor/types"
+)
+
+func Test_applyExtra_error(t *testing.T) {
+	testCases := []struct {
+		desc  string
+		extra []types.ExtraVersion
+	}{
+		{
+			desc:  "no URL and no archive",
+			extra: []types.ExtraVersion{{Name: "v1.7"}},
+		},
+		{
+			desc:  "invalid state",
+			extra: []types.ExtraVersion{{Name: "v1.7", URL: "https://v1.doc.traefik.io/traefik/", State: "foo"}},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			err := applyExtra([]optionVersion{{Name: "v1.7"}}, test.extra)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package menu
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+// BranchFilter filters the branches with include and exclude rules.
+// A rule is a version name, a glob (ex: "v2.0*"), a regular expression (ex: "/^v2\.\d+$/"), or a semver constraint (ex: "< v1.4").
+type BranchFilter struct {
+	include    []versionSelector
+	exclude    []versionSelector
+	keepMinors int
+}
+
+// NewBranchFilter creates a branch filter.
+// When include rules are defined, only the branches matching one of them are kept.
+// When keepMinors is defined, only the most recent minors of each major are kept.
+func NewBranchFilter(include, exclude []string, keepMinors int) (*BranchFilter, error) {
+	filter := &BranchFilter{keepMinors: keepMinors}
+
+	for _, value := range include {
+		selector, err := newVersionSelector(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid include rule: %w", err)
+		}
+
+		filter.include = append(filter.include, selector)
+	}
+
+	for _, value := range exclude {
+		selector, err := newVersionSelector(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exclude rule: %w", err)
+		}
+
+		filter.exclude = append(filter.exclude, selector)
+	}
+
+	return filter, nil
+}
+
+// Filter filters the branches (ex: "origin/v2.9"), and reports the rule excluding each branch in debug mode.
+func (f *BranchFilter) Filter(branches []string, debug bool) []string {
+	var candidates []string
+	for _, branch := range branches {
+		reason, excluded := f.isExcluded(strings.Replace(branch, baseRemote, "", 1))
+		if !excluded {
+			candidates = append(candidates, branch)
+			continue
+		}
+
+		if debug {
+			log.Printf("Branch %s excluded by %s", branch, reason)
+		}
+	}
+
+	if f.keepMinors <= 0 {
+		return candidates
+	}
+
+	return f.keepRecentMinors(candidates, debug)
+}
+
+// keepRecentMinors removes the semver branches which are not one of the most recent minors of their major.
+func (f *BranchFilter) keepRecentMinors(branches []string, debug bool) []string {
+	var names []string
+	for _, branch := range branches {
+		names = append(names, strings.Replace(branch, baseRemote, "", 1))
+	}
+
+	supported := getSupportedMinors(names, nil, f.keepMinors)
+
+	var kept []string
+	for i, branch := range branches {
+		v, err := parseVersion(names[i])
+		if err != nil {
+			kept = append(kept, branch)
+			continue
+		}
+
+		if _, ok := supported[v.Segments()[0]][v.Segments()[1]]; ok {
+			kept = append(kept, branch)
+			continue
+		}
+
+		if debug {
+			log.Printf("Branch %s excluded by the rule: keep %d minors per major", branch, f.keepMinors)
+		}
+	}
+
+	return kept
+}
+
+func (f *BranchFilter) isExcluded(name string) (string, bool) {
+	if len(f.include) > 0 && !matchAny(f.include, name) {
+		return "the include rules", true
+	}
+
+	for _, selector := range f.exclude {
+		if selector.matchName(name) {
+			return fmt.Sprintf("the exclude rule %q", selector.name), true
+		}
+	}
+
+	return "", false
+}
+
+func matchAny(selectors []versionSelector, name string) bool {
+	for _, selector := range selectors {
+		if selector.matchName(name) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,79 @@
+package menu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBranchFilter_Filter(t *testing.T) {
+	branches := []string{
+		"origin/v2.1",
+		"origin/v2.0.1",
+		"origin/v2.0",
+		"origin/v1.7",
+		"origin/v1.6",
+		"origin/v1.3",
+		"origin/v2.x-legacy",
+	}
+
+	testCases := []struct {
+		desc       string
+		include    []string
+		exclude    []string
+		keepMinors int
+		expected   []string
+	}{
+		{
+			desc:     "no rules",
+			expected: branches,
+		},
+		{
+			desc:     "exclude by name",
+			exclude:  []string{"v2.x-legacy"},
+			expected: []string{"origin/v2.1", "origin/v2.0.1", "origin/v2.0", "origin/v1.7", "origin/v1.6", "origin/v1.3"},
+		},
+		{
+			desc:     "exclude by constraint and glob",
+			exclude:  []string{"< v1.4", "v2.0*"},
+			expected: []string{"origin/v2.1", "origin/v1.7", "origin/v1.6", "origin/v2.x-legacy"},
+		},
+		{
+			desc:     "exclude by regular expression",
+			exclude:  []string{`/^v1\.\d+$/`},
+			expected: []string{"origin/v2.1", "origin/v2.0.1", "origin/v2.0", "origin/v2.x-legacy"},
+		},
+		{
+			desc:     "include and exclude",
+			include:  []string{">= 2.0"},
+			exclude:  []string{"v2.0.1"},
+			expected: []string{"origin/v2.1", "origin/v2.0"},
+		},
+		{
+			desc:       "keep the last minor of each major",
+			keepMinors: 1,
+			expected:   []string{"origin/v2.1", "origin/v1.7", "origin/v2.x-legacy"},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			filter, err := NewBranchFilter(test.include, test.exclude, test.keepMinors)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, filter.Filter(branches, true))
+		})
+	}
+}
+
+func TestNewBranchFilter_invalid(t *testing.T) {
+	_, err := NewBranchFilter(nil, []string{"/v2.[/"}, 0)
+	assert.Error(t, err)
+
+	_, err = NewBranchFilter([]string{"v2.["}, nil, 0)
+	assert.Error(t, err)
+}
@@ -0,0 +1,21 @@
+// Evaluates structorLatestURL in a fake browser.
+// Usage: node latest-url.js <menu JS file> <URL of the menu JS file> <URL of the page>
+var fs = require('fs');
+
+var script = fs.readFileSync(process.argv[2], 'utf8');
+var href = process.argv[4];
+
+global.window = {location: {href: href, protocol: href.split(':')[0] + ':', host: ''}};
+global.document = {
+  currentScript: {src: process.argv[3]},
+  body: null,
+  querySelector: function () {
+    return null;
+  },
+  addEventListener: function () {
+  },
+};
+
+(0, eval)(script);
+
+process.stdout.write(structorLatestURL(structorRoot, structorCurrent));
@@ -1,10 +1,26 @@
 var versions = [
-  {path: "master", text: "Experimental", selected: false },
-  {path: "v1.10", text: "v1.10 (RC)", selected: true },
-  {path: "", text: "v1.9 Latest", selected: false },
-  {path: "v1.8", text: "v1.8", selected: false },
+  {path: "master", url: "", text: "Experimental", selected: false },
+  {path: "v1.10", url: "", text: "v1.10 (RC)", selected: true },
+  {path: "", url: "", text: "v1.9 Latest", selected: false },
+  {path: "v1.8", url: "", text: "v1.8", selected: false },
 ];
 
+function versionURL(version) {
+  if (version.url) {
+    return version.url;
+  }
+
+  let url = window.location.protocol + "//" + window.location.host + "/";
+  // without base path, the sites served by doc.traefik.io are under the first segment of the path.
+  if ("/" === "/" && window.location.host === "doc.traefik.io") {
+    url = url + window.location.pathname.split('/')[1] + "/";
+  }
+  if (version.path) {
+    url = url + version.path + "/";
+  }
+  return url;
+}
+
 
 
 
@@ -85,13 +101,7 @@ function addMaterialMenu(elt, versions) {
     if (versions[i].selected) {
       a.classList.add('md-nav__link--active');
     }
-    a.href = window.location.protocol + "//" + window.location.host + "/";
-    if (window.location.host === "doc.traefik.io") {
-      a.href = a.href + window.location.pathname.split('/')[1] + "/";
-    }
-    if (versions[i].path) {
-      a.href = a.href + versions[i].path + "/";
-    }
+    a.href = versionURL(versions[i]);
     a.title = versions[i].text;
     a.text = versions[i].text;
 
@@ -115,13 +125,7 @@ function addMenu(elt, versions){
 
   for (let i = 0; i < versions.length; i++) {
     let opt = document.createElement('option');
-    opt.value = window.location.protocol + "//" + window.location.host + "/";
-    if (window.location.host === "doc.traefik.io") {
-      opt.value = opt.value + window.location.pathname.split('/')[1] + "/"
-    }
-    if (versions[i].path) {
-      opt.value = opt.value + versions[i].path + "/"
-    }
+    opt.value = versionURL(versions[i]);
     opt.text = versions[i].text;
     opt.selected = versions[i].selected;
     select.appendChild(opt);
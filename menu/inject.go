@@ -0,0 +1,365 @@
+package menu
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/traefik/structor/manifest"
+	"github.com/traefik/structor/types"
+	"golang.org/x/net/html"
+)
+
+const (
+	// InjectionManifest the menu files are added to the manifest (extra_javascript and extra_css) before the build.
+	InjectionManifest = "manifest"
+	// InjectionHTML the menu files are injected into the HTML files after the build.
+	InjectionHTML = "html"
+)
+
+// IsValidInjection checks if an injection mode is valid.
+func IsValidInjection(name string) bool {
+	return name == "" || name == InjectionManifest || name == InjectionHTML
+}
+
+// pageInjection the elements injected into an HTML page.
+type pageInjection struct {
+	scripts   []string
+	styles    []string
+	banner    string
+	noIndex   bool
+	canonical string
+}
+
+// BuildHTML Builds the menu of a version, and injects it into the HTML files generated by MkDocs.
+// The manifest is not modified, and it's only used (when it can be read) to detect the theme and the locale.
+func BuildHTML(versionsInfo types.VersionsInformation, branches []string, menuContent Content, siteDir string) error {
+	manifestFile := filepath.Join(versionsInfo.CurrentPath, manifest.FileName)
+
+	effective, err := manifest.ReadEffective(manifestFile)
+	if err != nil {
+		log.Printf("[WARN] failed to read manifest %s: %v", manifestFile, err)
+		effective = map[string]interface{}{}
+	}
+
+	if menuContent.Theme == ThemeAuto {
+		menuContent, err = menuContent.withTheme(detectTheme(effective))
+		if err != nil {
+			return err
+		}
+	}
+
+	return buildSite(versionsInfo, branches, menuContent, siteDir, getLocale(effective))
+}
+
+// buildSite writes the menu files into the site of a version, and injects them into its HTML files.
+func buildSite(versionsInfo types.VersionsInformation, branches []string, menuContent Content, siteDir, locale string) error {
+	versions, err := buildVersions(versionsInfo, branches)
+	if err != nil {
+		return fmt.Errorf("error when build versions: %w", err)
+	}
+
+	completeVersions(versions, versionsInfo)
+
+	model := newTemplateModel(versionsInfo, versions, versionsInfo.SiteURL, locale)
+
+	jsFilePath, err := writeJsFile(siteDir, menuContent, model)
+	if err != nil {
+		return err
+	}
+
+	cssFilePath, err := writeCSSFile(siteDir, menuContent, model)
+	if err != nil {
+		return err
+	}
+
+	assetFilePaths, err := writeAssets(siteDir, menuContent, model)
+	if err != nil {
+		return err
+	}
+
+	files := append([]string{jsFilePath, cssFilePath}, assetFilePaths...)
+
+	return injectSite(siteDir, model, files, versionsInfo.Settings.GetBanner())
+}
+
+// injectSite injects the files (paths relative to the site directory), the banner, and the meta tags into the HTML files of the site of a version.
+func injectSite(siteDir string, model templateModel, files []string, banner *types.Banner) error {
+	current := getSelectedVersion(model.Versions)
+
+	return filepath.WalkDir(siteDir, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() || !strings.EqualFold(filepath.Ext(filePath), ".html") {
+			return nil
+		}
+
+		pagePath, err := filepath.Rel(siteDir, filePath)
+		if err != nil {
+			return err
+		}
+
+		inj := pageInjection{}
+
+		for _, f := range files {
+			switch path.Ext(filepath.ToSlash(f)) {
+			case ".js":
+				inj.scripts = append(inj.scripts, getRelativeURL(siteDir, filePath, f))
+			case ".css":
+				inj.styles = append(inj.styles, getRelativeURL(siteDir, filePath, f))
+			}
+		}
+
+		// the link to the same page in the latest version.
+		latestURL := getRootURL(siteDir, filePath, current.Path) + getPageURL(pagePath)
+
+		inj.banner, err = buildBanner(banner, current, latestURL)
+		if err != nil {
+			return err
+		}
+
+		switch current.State {
+		case stateExperimental, statePreFinalRelease, stateObsolete, stateEOL:
+			inj.noIndex = true
+		}
+
+		if model.SiteURL != "" && current.State != stateLatest {
+			inj.canonical = strings.TrimSuffix(model.SiteURL, "/") + "/" + getPageURL(pagePath)
+		}
+
+		return injectFile(filePath, inj)
+	})
+}
+
+func getSelectedVersion(versions []optionVersion) optionVersion {
+	for _, v := range versions {
+		if v.Selected {
+			return v
+		}
+	}
+
+	return optionVersion{}
+}
+
+// getRootURL gets the URL of the root of the site from an HTML file of the site of a version.
+func getRootURL(siteDir, htmlFilePath, versionPath string) string {
+	root := getRelativeURL(siteDir, htmlFilePath, ".")
+
+	for _, segment := range strings.Split(versionPath, "/") {
+		if segment != "" {
+			root = path.Join(root, "..")
+		}
+	}
+
+	return root + "/"
+}
+
+// getPageURL gets the URL of a page from its path (ex: "foo/index.html" -> "foo/").
+func getPageURL(pagePath string) string {
+	pageURL := filepath.ToSlash(pagePath)
+
+	switch {
+	case pageURL == "index.html":
+		return ""
+	case strings.HasSuffix(pageURL, "/index.html"):
+		return strings.TrimSuffix(pageURL, "index.html")
+	default:
+		return pageURL
+	}
+}
+
+// getRelativeURL gets the URL of a file (path relative to the site directory) from an HTML file.
+func getRelativeURL(siteDir, htmlFilePath, filePath string) string {
+	rel, err := filepath.Rel(filepath.Dir(htmlFilePath), filepath.Join(siteDir, filePath))
+	if err != nil {
+		return filepath.ToSlash(filePath)
+	}
+
+	return filepath.ToSlash(rel)
+}
+
+func injectFile(filePath string, inj pageInjection) error {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+
+	b := &bytes.Buffer{}
+
+	err = injectPage(bytes.NewReader(content), b, inj)
+	if err != nil {
+		return fmt.Errorf("failed to inject the menu into %s: %w", filePath, err)
+	}
+
+	return os.WriteFile(filePath, b.Bytes(), os.ModePerm)
+}
+
+// injectPage copies an HTML page, and injects the elements.
+// The elements already present in the page (same scripts and styles, canonical link, robots meta) are replaced or not duplicated.
+func injectPage(r io.Reader, w io.Writer, inj pageInjection) error {
+	z := html.NewTokenizer(r)
+
+	injector := &pageInjector{inj: inj, present: map[string]struct{}{}}
+
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			if !errors.Is(z.Err(), io.EOF) {
+				return z.Err()
+			}
+
+			if !injector.bodyDone {
+				_, err := io.WriteString(w, buildBodyEnd(inj, injector.present))
+				return err
+			}
+
+			return nil
+		}
+
+		raw := string(z.Raw())
+
+		before, after, skip := injector.handle(z.Token())
+		if skip {
+			continue
+		}
+
+		_, err := io.WriteString(w, before+raw+after)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// pageInjector the state of the injection into a page.
+type pageInjector struct {
+	inj         pageInjection
+	present     map[string]struct{}
+	headDone    bool
+	bodyStarted bool
+	bodyDone    bool
+}
+
+// handle gets the elements to write before and after a token, or if the token must be removed.
+func (p *pageInjector) handle(token html.Token) (string, string, bool) {
+	switch {
+	case isSkipped(token, p.inj):
+		return "", "", true
+
+	case token.Type != html.EndTagToken && (token.Data == "script" || token.Data == "link"):
+		p.present[getAttr(token, "src")+getAttr(token, "href")] = struct{}{}
+
+	case token.Type == html.EndTagToken && token.Data == "head":
+		return p.endHead(), "", false
+
+	case token.Type == html.StartTagToken && !p.bodyStarted && (token.Data == "body" || !isHeadElement(token.Data)):
+		return p.startBody(token.Data == "body")
+
+	case token.Type == html.EndTagToken && token.Data == "body" && !p.bodyDone:
+		p.bodyDone = true
+		return buildBodyEnd(p.inj, p.present), "", false
+	}
+
+	return "", "", false
+}
+
+// endHead gets the elements injected at the end of the head, only once.
+func (p *pageInjector) endHead() string {
+	if p.headDone {
+		return ""
+	}
+
+	p.headDone = true
+
+	return buildHeadEnd(p.inj, p.present)
+}
+
+// startBody gets the elements injected at the start of the body.
+// The end of the head can be omitted, and the start of the body too: the elements are injected before the first element of the body.
+func (p *pageInjector) startBody(bodyTag bool) (string, string, bool) {
+	p.bodyStarted = true
+
+	if bodyTag {
+		return p.endHead(), p.inj.banner, false
+	}
+
+	return p.endHead() + p.inj.banner, "", false
+}
+
+// isHeadElement checks if an element is the root element, or an element of the head.
+func isHeadElement(name string) bool {
+	switch name {
+	case "html", "head", "title", "base", "link", "meta", "style", "script", "noscript", "template":
+		return true
+	default:
+		return false
+	}
+}
+
+// isSkipped checks if a tag of the page must be removed because it's replaced.
+func isSkipped(token html.Token, inj pageInjection) bool {
+	switch {
+	case token.Type == html.EndTagToken:
+		return false
+	case token.Data == "link" && inj.canonical != "":
+		return strings.EqualFold(getAttr(token, "rel"), "canonical")
+	case token.Data == "meta" && inj.noIndex:
+		return strings.EqualFold(getAttr(token, "name"), "robots")
+	default:
+		return false
+	}
+}
+
+func buildHeadEnd(inj pageInjection, present map[string]struct{}) string {
+	b := &strings.Builder{}
+
+	if inj.noIndex {
+		b.WriteString(`<meta name="robots" content="noindex">` + "\n")
+	}
+
+	if inj.canonical != "" {
+		fmt.Fprintf(b, `<link rel="canonical" href="%s">`+"\n", html.EscapeString(inj.canonical))
+	}
+
+	for _, style := range inj.styles {
+		if _, ok := present[style]; ok {
+			continue
+		}
+
+		fmt.Fprintf(b, `<link rel="stylesheet" href="%s">`+"\n", html.EscapeString(style))
+	}
+
+	return b.String()
+}
+
+func buildBodyEnd(inj pageInjection, present map[string]struct{}) string {
+	b := &strings.Builder{}
+
+	for _, script := range inj.scripts {
+		if _, ok := present[script]; ok {
+			continue
+		}
+
+		fmt.Fprintf(b, `<script src="%s"></script>`+"\n", html.EscapeString(script))
+	}
+
+	return b.String()
+}
+
+func getAttr(token html.Token, name string) string {
+	for _, attr := range token.Attr {
+		if attr.Key == name {
+			return attr.Val
+		}
+	}
+
+	return ""
+}
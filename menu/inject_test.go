@@ -0,0 +1,149 @@
+package menu
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/structor/types"
+)
+
+func Test_injectPage(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		page     string
+		inj      pageInjection
+		expected string
+	}{
+		{
+			desc: "scripts and styles",
+			page: "<html><head><title>Foo</title></head><body><p>Foo</p></body></html>",
+			inj: pageInjection{
+				scripts: []string{"theme/js/structor-menu.js"},
+				styles:  []string{"theme/css/structor-menu.css"},
+			},
+			expected: "<html><head><title>Foo</title>" +
+				`<link rel="stylesheet" href="theme/css/structor-menu.css">` + "\n" +
+				"</head><body><p>Foo</p>" +
+				`<script src="theme/js/structor-menu.js"></script>` + "\n" +
+				"</body></html>",
+		},
+		{
+			desc: "already present",
+			page: `<html><head><link rel="stylesheet" href="theme/css/structor-menu.css"></head><body><script src="theme/js/structor-menu.js"></script></body></html>`,
+			inj: pageInjection{
+				scripts: []string{"theme/js/structor-menu.js"},
+				styles:  []string{"theme/css/structor-menu.css"},
+			},
+			expected: `<html><head><link rel="stylesheet" href="theme/css/structor-menu.css"></head><body><script src="theme/js/structor-menu.js"></script></body></html>`,
+		},
+		{
+			desc: "banner and meta",
+			page: `<html><head><meta name="robots" content="index"><link rel="canonical" href="https://example.com/v1.0/foo/"></head><body class="foo"><p>Foo</p></body></html>`,
+			inj: pageInjection{
+				banner:    `<div class="structor-banner">Outdated</div>`,
+				noIndex:   true,
+				canonical: "https://example.com/foo/",
+			},
+			expected: `<html><head>` +
+				`<meta name="robots" content="noindex">` + "\n" +
+				`<link rel="canonical" href="https://example.com/foo/">` + "\n" +
+				`</head><body class="foo"><div class="structor-banner">Outdated</div><p>Foo</p></body></html>`,
+		},
+		{
+			desc: "no end of head",
+			page: `<html><head><title>Foo</title><body><p>Foo</p></body></html>`,
+			inj: pageInjection{
+				styles:  []string{"theme/css/structor-menu.css"},
+				banner:  `<div class="structor-banner">Outdated</div>`,
+				noIndex: true,
+			},
+			expected: `<html><head><title>Foo</title>` +
+				`<meta name="robots" content="noindex">` + "\n" +
+				`<link rel="stylesheet" href="theme/css/structor-menu.css">` + "\n" +
+				`<body><div class="structor-banner">Outdated</div><p>Foo</p></body></html>`,
+		},
+		{
+			desc: "no end of head and no body",
+			page: `<meta charset="utf-8"><title>Foo</title><link rel="canonical" href="https://example.com/v1.0/"><p>Foo</p>`,
+			inj: pageInjection{
+				banner:    `<div class="structor-banner">Outdated</div>`,
+				canonical: "https://example.com/",
+			},
+			expected: `<meta charset="utf-8"><title>Foo</title>` +
+				`<link rel="canonical" href="https://example.com/">` + "\n" +
+				`<div class="structor-banner">Outdated</div><p>Foo</p>`,
+		},
+		{
+			desc: "no body",
+			page: "<p>Foo</p>",
+			inj: pageInjection{
+				scripts: []string{"theme/js/structor-menu.js"},
+			},
+			expected: "<p>Foo</p>" + `<script src="theme/js/structor-menu.js"></script>` + "\n",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			b := &strings.Builder{}
+
+			err := injectPage(strings.NewReader(test.page), b, test.inj)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, b.String())
+		})
+	}
+}
+
+func TestBuildHTML(t *testing.T) {
+	dir, err := os.MkdirTemp("", "structor-test")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	err = os.WriteFile(filepath.Join(dir, "mkdocs.yml"), []byte("site_url: https://example.org/\ntheme:\n  name: material\n"), os.ModePerm)
+	require.NoError(t, err)
+
+	siteDir := filepath.Join(dir, "site")
+
+	err = os.MkdirAll(filepath.Join(siteDir, "bar"), os.ModePerm)
+	require.NoError(t, err)
+
+	err = os.WriteFile(filepath.Join(siteDir, "bar", "index.html"), []byte("<html><head></head><body></body></html>"), os.ModePerm)
+	require.NoError(t, err)
+
+	// the site URL of the manifest is ignored.
+	versionsInfo := types.VersionsInformation{
+		Current:     "v1.5",
+		Latest:      "v1.7.0",
+		CurrentPath: dir,
+		SiteURL:     "https://example.com/foo/",
+	}
+
+	err = BuildHTML(versionsInfo, []string{"origin/v1.7", "origin/v1.6", "origin/v1.5"}, Content{Theme: ThemeAuto}, siteDir)
+	require.NoError(t, err)
+
+	assert.FileExists(t, filepath.Join(siteDir, "theme", "js", menuJsFileName))
+	assert.FileExists(t, filepath.Join(siteDir, "theme", "css", menuCSSFileName))
+
+	page, err := os.ReadFile(filepath.Join(siteDir, "bar", "index.html"))
+	require.NoError(t, err)
+
+	expected := "<html><head>" +
+		`<meta name="robots" content="noindex">` + "\n" +
+		`<link rel="canonical" href="https://example.com/foo/bar/">` + "\n" +
+		`<link rel="stylesheet" href="../theme/css/structor-menu.css">` + "\n" +
+		"</head><body>" +
+		`<div class="structor-banner structor-banner-obsolete" style="padding: .5em; text-align: center; background-color: #fff3cd; color: #664d03;">` +
+		`This documentation is for an outdated version (v1.5). <a href="../../bar/">Go to the latest version.</a></div>` +
+		`<script src="../theme/js/structor-menu.js"></script>` + "\n" +
+		"</body></html>"
+
+	assert.Equal(t, expected, string(page))
+}
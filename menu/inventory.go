@@ -0,0 +1,162 @@
+package menu
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/traefik/structor/types"
+)
+
+// InventoryFileName the name of the inventory of the versions, at the root of the site.
+const InventoryFileName = "versions.json"
+
+// Inventory the versions of a site.
+type Inventory struct {
+	Latest       string             `json:"latest"`
+	Experimental string             `json:"experimental,omitempty"`
+	Versions     []InventoryVersion `json:"versions"`
+}
+
+// InventoryVersion a version of the inventory.
+type InventoryVersion struct {
+	Name   string    `json:"name"`
+	Text   string    `json:"text"`
+	Path   string    `json:"path"`
+	URL    string    `json:"url,omitempty"`
+	State  string    `json:"state,omitempty"`
+	Commit string    `json:"commit,omitempty"`
+	Date   time.Time `json:"date,omitempty"`
+	// Hidden the version is not listed in the menus of the other versions (hidden experimental branch, or hidden by a visibility rule).
+	Hidden bool `json:"hidden,omitempty"`
+}
+
+// BuildInventory builds the inventory of the versions.
+func BuildInventory(versionsInfo types.VersionsInformation, branches []string) (Inventory, error) {
+	versions, err := buildAllVersions(versionsInfo, branches)
+	if err != nil {
+		return Inventory{}, fmt.Errorf("error when build versions: %w", err)
+	}
+
+	completeVersions(versions, versionsInfo)
+
+	hidden, err := getHiddenVersions(versionsInfo.Settings)
+	if err != nil {
+		return Inventory{}, err
+	}
+
+	inventory := Inventory{
+		Latest:       versionsInfo.Latest,
+		Experimental: versionsInfo.Experimental,
+	}
+
+	for _, v := range versions {
+		inventory.Versions = append(inventory.Versions, InventoryVersion{
+			Name:   v.Name,
+			Text:   v.Text,
+			Path:   v.Path,
+			URL:    v.URL,
+			State:  v.State,
+			Commit: v.Commit,
+			Date:   v.Date,
+			Hidden: hidden(v.Name),
+		})
+	}
+
+	return inventory, nil
+}
+
+// getHiddenVersions gets a function checking if a version is hidden: a hidden experimental branch, or a version hidden by a visibility rule.
+func getHiddenVersions(settings *types.Settings) (func(name string) bool, error) {
+	rules, err := newVisibilityRules(settings.GetVisibility())
+	if err != nil {
+		return nil, err
+	}
+
+	return func(name string) bool {
+		if e, ok := settings.FindExperimental(name); ok && e.Hidden {
+			return true
+		}
+
+		rule, ok := findVisibilityRule(rules, name)
+
+		return ok && rule.hidden
+	}, nil
+}
+
+// Write writes the inventory at the root of the site.
+func (i Inventory) Write(siteDir string) error {
+	content, err := json.MarshalIndent(i, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(siteDir, InventoryFileName), content, os.ModePerm)
+}
+
+// ReadInventory reads the inventory of the versions of a site.
+func ReadInventory(siteDir string) (Inventory, error) {
+	content, err := os.ReadFile(filepath.Join(siteDir, InventoryFileName))
+	if err != nil {
+		return Inventory{}, err
+	}
+
+	var inventory Inventory
+	err = json.Unmarshal(content, &inventory)
+	if err != nil {
+		return Inventory{}, fmt.Errorf("invalid inventory: %w", err)
+	}
+
+	return inventory, nil
+}
+
+// GetBranches gets the version names of the inventory.
+func (i Inventory) GetBranches() []string {
+	var branches []string
+	for _, v := range i.Versions {
+		branches = append(branches, v.Name)
+	}
+
+	return branches
+}
+
+// GetLatestVersion gets the name of the latest version, served at the root of the site.
+func (i Inventory) GetLatestVersion() string {
+	for _, v := range i.Versions {
+		if v.Path == "" && v.URL == "" {
+			return v.Name
+		}
+	}
+
+	return ""
+}
+
+// GetCommits gets the commit information of the versions of the inventory, by version name.
+func (i Inventory) GetCommits() map[string]types.CommitInformation {
+	commits := make(map[string]types.CommitInformation)
+	for _, v := range i.Versions {
+		if v.Commit != "" {
+			commits[v.Name] = types.CommitInformation{SHA: v.Commit, Date: v.Date}
+		}
+	}
+
+	return commits
+}
+
+// SameMenu checks if the versions of the menu are the same in both inventories (the commits are ignored).
+func (i Inventory) SameMenu(other Inventory) bool {
+	if i.Latest != other.Latest || i.Experimental != other.Experimental || len(i.Versions) != len(other.Versions) {
+		return false
+	}
+
+	for idx, v := range i.Versions {
+		o := other.Versions[idx]
+		if v.Name != o.Name || v.Text != o.Text || v.Path != o.Path || v.URL != o.URL || v.State != o.State || v.Hidden != o.Hidden {
+			return false
+		}
+	}
+
+	return true
+}
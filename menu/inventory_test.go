@@ -0,0 +1,139 @@
+package menu
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/structor/types"
+)
+
+func TestBuildInventory(t *testing.T) {
+	siteDir, err := os.MkdirTemp("", "structor-test")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(siteDir) }()
+
+	date := time.Date(2023, time.February, 14, 10, 11, 12, 0, time.UTC)
+
+	versionsInfo := types.VersionsInformation{
+		Latest:       "v2.1.0",
+		Experimental: "master",
+		Commits: map[string]types.CommitInformation{
+			"master": {SHA: "aaa", Date: date},
+			"v2.1":   {SHA: "bbb", Date: date},
+		},
+		Settings: &types.Settings{
+			Extra:        []types.ExtraVersion{{Name: "v1.7", URL: "https://v1.doc.traefik.io/traefik/"}},
+			Experimental: []types.ExperimentalBranch{{Name: "feature/foo", Hidden: true}},
+			Visibility:   []types.VisibilityRule{{Versions: "v2.0", Hidden: true}},
+		},
+	}
+
+	built, err := BuildInventory(versionsInfo, []string{"origin/master", "origin/feature/foo", "origin/v2.1", "origin/v2.0"})
+	require.NoError(t, err)
+
+	err = built.Write(siteDir)
+	require.NoError(t, err)
+
+	inventory, err := ReadInventory(siteDir)
+	require.NoError(t, err)
+
+	expected := Inventory{
+		Latest:       "v2.1.0",
+		Experimental: "master",
+		Versions: []InventoryVersion{
+			{Name: "master", Text: "Experimental", Path: "master", State: stateExperimental, Commit: "aaa", Date: date},
+			{Name: "feature/foo", Text: "Experimental", Path: "feature/foo", State: stateExperimental, Hidden: true},
+			{Name: "v2.1", Text: "v2.1 Latest", Path: "", State: stateLatest, Commit: "bbb", Date: date},
+			{Name: "v2.0", Text: "v2.0", Path: "v2.0", State: stateObsolete, Hidden: true},
+			{Name: "v1.7", Text: "v1.7", Path: "", URL: "https://v1.doc.traefik.io/traefik/"},
+		},
+	}
+	assert.Equal(t, expected, inventory)
+
+	assert.Equal(t, []string{"master", "feature/foo", "v2.1", "v2.0", "v1.7"}, inventory.GetBranches())
+	assert.Equal(t, map[string]types.CommitInformation{
+		"master": {SHA: "aaa", Date: date},
+		"v2.1":   {SHA: "bbb", Date: date},
+	}, inventory.GetCommits())
+}
+
+func TestInventory_SameMenu(t *testing.T) {
+	date := time.Date(2023, time.February, 14, 10, 11, 12, 0, time.UTC)
+
+	inventory := Inventory{
+		Latest: "v2.1.0",
+		Versions: []InventoryVersion{
+			{Name: "v2.1", Text: "v2.1 Latest", State: stateLatest, Commit: "aaa", Date: date},
+			{Name: "v2.0", Text: "v2.0", Path: "v2.0", Commit: "bbb", Date: date},
+		},
+	}
+
+	testCases := []struct {
+		desc     string
+		other    Inventory
+		expected bool
+	}{
+		{
+			desc:     "same",
+			other:    inventory,
+			expected: true,
+		},
+		{
+			desc: "other commits",
+			other: Inventory{
+				Latest: "v2.1.0",
+				Versions: []InventoryVersion{
+					{Name: "v2.1", Text: "v2.1 Latest", State: stateLatest, Commit: "ccc", Date: date.Add(time.Hour)},
+					{Name: "v2.0", Text: "v2.0", Path: "v2.0", Commit: "bbb", Date: date},
+				},
+			},
+			expected: true,
+		},
+		{
+			desc: "other latest",
+			other: Inventory{
+				Latest: "v2.1.1",
+				Versions: []InventoryVersion{
+					{Name: "v2.1", Text: "v2.1 Latest", State: stateLatest},
+					{Name: "v2.0", Text: "v2.0", Path: "v2.0"},
+				},
+			},
+			expected: false,
+		},
+		{
+			desc: "new version",
+			other: Inventory{
+				Latest: "v2.1.0",
+				Versions: []InventoryVersion{
+					{Name: "v2.2", Text: "v2.2 RC", Path: "v2.2", State: statePreFinalRelease},
+					{Name: "v2.1", Text: "v2.1 Latest", State: stateLatest},
+					{Name: "v2.0", Text: "v2.0", Path: "v2.0"},
+				},
+			},
+			expected: false,
+		},
+		{
+			desc: "other state",
+			other: Inventory{
+				Latest: "v2.1.0",
+				Versions: []InventoryVersion{
+					{Name: "v2.1", Text: "v2.1 Latest", State: stateLatest},
+					{Name: "v2.0", Text: "v2.0", Path: "v2.0", State: stateObsolete},
+				},
+			},
+			expected: false,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, inventory.SameMenu(test.other))
+		})
+	}
+}
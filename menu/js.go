@@ -2,13 +2,10 @@ package menu
 
 import (
 	"fmt"
-	"os"
-	"path/filepath"
 	"sort"
 	"strings"
-	"text/template"
+	"time"
 
-	"github.com/Masterminds/sprig/v3"
 	"github.com/hashicorp/go-version"
 	"github.com/traefik/structor/types"
 )
@@ -20,107 +17,106 @@ const (
 	stateExperimental    = "EXPERIMENTAL"
 	statePreFinalRelease = "PRE_FINAL_RELEASE"
 	stateObsolete        = "OBSOLETE"
+	stateLTS             = "LTS"
+	stateEOL             = "EOL"
 )
 
 type optionVersion struct {
-	Path     string
-	Text     string
-	Name     string
-	State    string
-	Selected bool
+	Path          string            `description:"The path of the version, relative to the root of the site (empty for the latest version)."`
+	Text          string            `description:"The display label of the version."`
+	Name          string            `description:"The name of the version (branch name)."`
+	State         string            `description:"The state of the version (see States)."`
+	Selected      bool              `description:"True if the version is the current version."`
+	Commit        string            `description:"The source commit SHA of the version."`
+	Date          time.Time         `description:"The source commit date of the version."`
+	Tag           string            `description:"The most recent release tag matching the version (same major and minor), empty if none."`
+	Aliases       []string          `description:"The aliases of the version (ex: 'latest', 'experimental')."`
+	NewestOfMajor bool              `description:"True if the version is the newest version of its major."`
+	EOL           time.Time         `description:"The end of life date of the version (lifecycle policy), zero if not defined."`
+	Labels        map[string]string `description:"The translated display labels of the version, by locale (see the 'Label' function)."`
+	URL           string            `description:"The URL of the version: the external URL, or the absolute URL when the site URL or the base path is defined. Empty when the URL is relative to the root of the site."`
 }
 
-func writeJsFile(manifestDocsDir string, menuContent Content, versionsInfo types.VersionsInformation, branches []string) (string, error) {
+func writeJsFile(manifestDocsDir string, menuContent Content, model templateModel) (string, error) {
 	if len(menuContent.Js) == 0 {
 		return "", nil
 	}
 
-	jsDir := filepath.Join(manifestDocsDir, "theme", "js")
-	if _, errStat := os.Stat(jsDir); os.IsNotExist(errStat) {
-		errDir := os.MkdirAll(jsDir, os.ModePerm)
-		if errDir != nil {
-			return "", fmt.Errorf("error when create JS folder: %w", errDir)
-		}
-	}
+	return writeTemplateFile(manifestDocsDir, "js", menuJsFileName, string(menuContent.Js), model)
+}
 
-	menuFilePath := filepath.Join(jsDir, menuJsFileName)
-	errBuild := buildJSFile(menuFilePath, versionsInfo, branches, string(menuContent.Js))
-	if errBuild != nil {
-		return "", errBuild
+func buildVersions(versionsInfo types.VersionsInformation, branches []string) ([]optionVersion, error) {
+	versions, err := buildAllVersions(versionsInfo, branches)
+	if err != nil {
+		return nil, err
 	}
 
-	return filepath.Join("theme", "js", menuJsFileName), nil
+	versions = hideExperimental(versions, versionsInfo.Settings.GetExperimental())
+
+	return applyVisibility(versions, versionsInfo.Settings.GetVisibility(), versionsInfo.Current)
 }
 
-func buildJSFile(filePath string, versionsInfo types.VersionsInformation, branches []string, menuTemplate string) error {
-	defaultFuncMap := sprig.TxtFuncMap()
-	defaultFuncMap["IsObsolete"] = func(versions []optionVersion, current string) bool {
-		for _, v := range versions {
-			if v.Name == current && v.State == stateObsolete {
-				return true
-			}
-		}
-		return false
-	}
+// buildAllVersions builds the versions, including the versions which are not listed in the menu of the current version.
+func buildAllVersions(versionsInfo types.VersionsInformation, branches []string) ([]optionVersion, error) {
+	extra := versionsInfo.Settings.GetExtra()
 
-	temp := template.New("menu-js").Funcs(defaultFuncMap)
+	names := append(append([]string{}, branches...), getExtraNames(extra)...)
 
-	_, err := temp.Parse(menuTemplate)
+	versions, err := buildBranchVersions(versionsInfo, parseBranches(names))
 	if err != nil {
-		return fmt.Errorf("error during parsing template: %w", err)
+		return nil, err
 	}
 
-	versions, err := buildVersions(versionsInfo.Current, branches, versionsInfo.Latest, versionsInfo.Experimental)
+	err = applyExtra(versions, extra)
 	if err != nil {
-		return fmt.Errorf("error when build versions: %w", err)
+		return nil, err
 	}
 
-	model := struct {
-		Latest   string
-		Current  string
-		Versions []optionVersion
-	}{
-		Latest:   versionsInfo.Latest,
-		Current:  versionsInfo.Current,
-		Versions: versions,
-	}
+	setURLs(versions, versionsInfo.SiteURL, versionsInfo.BasePath)
 
-	f, err := os.Create(filePath)
+	err = setLabels(versions, versionsInfo.Settings)
 	if err != nil {
-		return fmt.Errorf("error when create menu file: %w", err)
+		return nil, err
 	}
 
-	return temp.Execute(f, model)
+	return versions, nil
 }
 
-func buildVersions(currentVersion string, branches []string, latestTagName, experimentalBranchName string) ([]optionVersion, error) {
-	latestVersion, err := version.NewVersion(latestTagName)
+func buildBranchVersions(versionsInfo types.VersionsInformation, rawVersions []string) ([]optionVersion, error) {
+	latestVersion, err := version.NewVersion(versionsInfo.Latest)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse latest tag version %s: %w", latestTagName, err)
+		return nil, fmt.Errorf("failed to parse latest tag version %s: %w", versionsInfo.Latest, err)
 	}
 
-	rawVersions, heads := parseBranches(branches)
+	lc, err := newLifecycle(versionsInfo.Settings.GetLifecycle(), rawVersions, latestVersion, time.Now())
+	if err != nil {
+		return nil, err
+	}
 
 	var versions []optionVersion
+	var nonSemverNames []string
 	for _, versionName := range rawVersions {
-		selected := currentVersion == versionName
+		selected := versionsInfo.Current == versionName
 
-		switch versionName {
-		case latestTagName:
-			// skip, because we must use the branch instead of the tag
-		case experimentalBranchName:
+		if experimentalPath, ok := getExperimentalPath(versionsInfo, versionName); ok {
 			versions = append(versions, optionVersion{
-				Path:     experimentalBranchName,
-				Text:     "Experimental",
-				Name:     experimentalBranchName,
+				Path:     experimentalPath,
+				Name:     versionName,
 				State:    stateExperimental,
 				Selected: selected,
 			})
 
+			continue
+		}
+
+		switch versionName {
+		case versionsInfo.Latest:
+			// skip, because we must use the branch instead of the tag
 		default:
-			simpleVersion, err := version.NewVersion(versionName)
+			simpleVersion, err := parseVersion(versionName)
 			if err != nil {
-				return nil, fmt.Errorf("failed to parse version %s: %w", versionName, err)
+				nonSemverNames = append(nonSemverNames, versionName)
+				continue
 			}
 
 			v := optionVersion{
@@ -131,63 +127,117 @@ func buildVersions(currentVersion string, branches []string, latestTagName, expe
 			switch {
 			case simpleVersion.GreaterThan(latestVersion):
 				v.Path = versionName
-				v.Text = versionName + " RC"
 				v.State = statePreFinalRelease
 			case sameMinor(simpleVersion, latestVersion):
 				// latest version
-				v.Text = versionName + " Latest"
 				v.State = stateLatest
 			default:
 				v.Path = versionName
-				v.Text = versionName
-				if !isHeads(heads, simpleVersion) {
-					v.State = stateObsolete
-				}
+				v.State, v.EOL = lc.getState(versionName, simpleVersion)
 			}
 
 			versions = append(versions, v)
 		}
 	}
 
-	return versions, nil
-}
+	// the non-semver versions are after the semver versions.
+	nonSemverVersions, err := buildNonSemverVersions(versionsInfo, nonSemverNames)
+	if err != nil {
+		return nil, err
+	}
 
-func parseBranches(branches []string) ([]string, map[int]*version.Version) {
-	heads := map[int]*version.Version{}
+	return append(versions, nonSemverVersions...), nil
+}
 
-	var rawVersions []string
-	for _, branch := range branches {
-		versionName := strings.Replace(branch, baseRemote, "", 1)
-		rawVersions = append(rawVersions, versionName)
+// completeVersions adds the git information, the aliases, and the major information to the versions.
+func completeVersions(versions []optionVersion, versionsInfo types.VersionsInformation) {
+	newest := map[int]*version.Version{}
 
-		v, err := version.NewVersion(versionName)
+	for _, v := range versions {
+		simpleVersion, err := parseVersion(v.Name)
 		if err != nil {
 			continue
 		}
 
-		if p, ok := heads[v.Segments()[0]]; ok {
-			if v.GreaterThan(p) {
-				heads[v.Segments()[0]] = v
+		major := simpleVersion.Segments()[0]
+		if n, ok := newest[major]; !ok || simpleVersion.GreaterThan(n) {
+			newest[major] = simpleVersion
+		}
+	}
+
+	for i, v := range versions {
+		commit := versionsInfo.Commits[v.Name]
+		versions[i].Commit = commit.SHA
+		versions[i].Date = commit.Date
+		versions[i].Tag = findTag(v.Name, versionsInfo.Tags)
+
+		switch v.State {
+		case stateLatest:
+			versions[i].Aliases = []string{"latest"}
+		case stateExperimental:
+			if v.Name == versionsInfo.Experimental {
+				versions[i].Aliases = []string{"experimental"}
 			}
-		} else {
-			heads[v.Segments()[0]] = v
+		}
+
+		if simpleVersion, err := parseVersion(v.Name); err == nil {
+			versions[i].NewestOfMajor = simpleVersion.Equal(newest[simpleVersion.Segments()[0]])
 		}
 	}
+}
 
-	sort.Slice(rawVersions, func(i, j int) bool {
-		vi, err := version.NewVersion(rawVersions[i])
-		if err != nil {
-			return true
+// findTag finds the most recent tag with the same major and minor as the version.
+func findTag(versionName string, tags []string) string {
+	simpleVersion, err := parseVersion(versionName)
+	if err != nil {
+		return ""
+	}
+
+	var tagName string
+	var tagVersion *version.Version
+
+	for _, tag := range tags {
+		v, err := version.NewVersion(tag)
+		if err != nil || !sameMinor(v, simpleVersion) {
+			continue
 		}
-		vj, err := version.NewVersion(rawVersions[j])
-		if err != nil {
-			return false
+
+		if tagVersion == nil || v.GreaterThan(tagVersion) {
+			tagName = tag
+			tagVersion = v
 		}
+	}
 
-		return vj.LessThanOrEqual(vi)
+	return tagName
+}
+
+// parseBranches gets the unique version names from the branches:
+// the non-semver names first (in the order of the branches), then the semver names from the newest to the oldest.
+func parseBranches(branches []string) []string {
+	var rawVersions []string
+	seen := map[string]struct{}{}
+	for _, branch := range branches {
+		name := strings.Replace(branch, baseRemote, "", 1)
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+
+		rawVersions = append(rawVersions, name)
+	}
+
+	sort.SliceStable(rawVersions, func(i, j int) bool {
+		vi, errI := parseVersion(rawVersions[i])
+		vj, errJ := parseVersion(rawVersions[j])
+
+		if errI != nil || errJ != nil {
+			return errI != nil && errJ == nil
+		}
+
+		return vi.GreaterThan(vj)
 	})
 
-	return rawVersions, heads
+	return rawVersions
 }
 
 func sameMinor(v1, v2 *version.Version) bool {
@@ -196,13 +246,3 @@ func sameMinor(v1, v2 *version.Version) bool {
 
 	return v1Parts[0] == v2Parts[0] && v1Parts[1] == v2Parts[1]
 }
-
-func isHeads(heads map[int]*version.Version, v *version.Version) bool {
-	for _, head := range heads {
-		if v.Equal(head) {
-			return true
-		}
-	}
-
-	return false
-}
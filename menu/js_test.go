@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -117,7 +118,10 @@ var foo = [
 
 			jsFile := filepath.Join(dir, "menu.js")
 
-			err = buildJSFile(jsFile, test.versionsInfo, test.branches, test.jsTemplate)
+			versions, err := buildVersions(test.versionsInfo, test.branches)
+			require.NoError(t, err)
+
+			err = buildFile(jsFile, "menu-js", test.jsTemplate, newTemplateModel(test.versionsInfo, versions, "", ""))
 			require.NoError(t, err)
 
 			assert.FileExists(t, jsFile)
@@ -137,6 +141,10 @@ func Test_buildVersions(t *testing.T) {
 		latestTagName          string
 		experimentalBranchName string
 		currentVersion         string
+		lifecycle              *types.Lifecycle
+		nonSemver              *types.NonSemver
+		extra                  []types.ExtraVersion
+		experimental           []types.ExperimentalBranch
 		expected               []optionVersion
 	}{
 		{
@@ -223,6 +231,145 @@ func Test_buildVersions(t *testing.T) {
 				{Path: "v1.4", Text: "v1.4", Name: "v1.4", State: stateObsolete, Selected: true},
 			},
 		},
+		{
+			desc:                   "keep 2 minors per major",
+			branches:               []string{"origin/v2.10", "origin/v2.9", "origin/v2.8", "origin/v2.7", "origin/master", "origin/v1.7", "origin/v1.6", "origin/v1.5"},
+			latestTagName:          "v2.9.0",
+			experimentalBranchName: "master",
+			currentVersion:         "v2.8",
+			lifecycle:              &types.Lifecycle{KeepMinors: 2},
+			expected: []optionVersion{
+				{Path: "master", Text: "Experimental", Name: "master", State: stateExperimental, Selected: false},
+				{Path: "v2.10", Text: "v2.10 RC", Name: "v2.10", State: statePreFinalRelease, Selected: false},
+				{Path: "", Text: "v2.9 Latest", Name: "v2.9", State: stateLatest, Selected: false},
+				{Path: "v2.8", Text: "v2.8", Name: "v2.8", State: "", Selected: true},
+				{Path: "v2.7", Text: "v2.7", Name: "v2.7", State: stateObsolete, Selected: false},
+				{Path: "v1.7", Text: "v1.7", Name: "v1.7", State: "", Selected: false},
+				{Path: "v1.6", Text: "v1.6", Name: "v1.6", State: "", Selected: false},
+				{Path: "v1.5", Text: "v1.5", Name: "v1.5", State: stateObsolete, Selected: false},
+			},
+		},
+		{
+			desc:           "lifecycle rules",
+			branches:       []string{"origin/v2.9", "origin/v2.8", "origin/v2.7", "origin/v1.7", "origin/v1.6", "origin/v1.5"},
+			latestTagName:  "v2.9.0",
+			currentVersion: "v2.9",
+			lifecycle: &types.Lifecycle{
+				Rules: []types.LifecycleRule{
+					{Versions: "v2.7", State: "lts", EOL: time.Date(2999, time.January, 1, 0, 0, 0, 0, time.UTC)},
+					{Versions: "v1.6", State: "supported"},
+					{Versions: "< 1.6", State: "eol"},
+					{Versions: "~> 1.7", State: "lts", EOL: time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)},
+				},
+			},
+			expected: []optionVersion{
+				{Path: "", Text: "v2.9 Latest", Name: "v2.9", State: stateLatest, Selected: true},
+				{Path: "v2.8", Text: "v2.8", Name: "v2.8", State: stateObsolete, Selected: false},
+				{Path: "v2.7", Text: "v2.7", Name: "v2.7", State: stateLTS, Selected: false, EOL: time.Date(2999, time.January, 1, 0, 0, 0, 0, time.UTC)},
+				{Path: "v1.7", Text: "v1.7", Name: "v1.7", State: stateEOL, Selected: false, EOL: time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)},
+				{Path: "v1.6", Text: "v1.6", Name: "v1.6", State: "", Selected: false},
+				{Path: "v1.5", Text: "v1.5", Name: "v1.5", State: stateEOL, Selected: false},
+			},
+		},
+		{
+			desc:                   "non-semver versions",
+			branches:               []string{"origin/v2.x-legacy", "origin/v1.7", "origin/master", "origin/docs10", "origin/v3.x-beta-docs", "origin/docs9", "origin/v2.0"},
+			latestTagName:          "v2.0.0",
+			experimentalBranchName: "master",
+			currentVersion:         "docs9",
+			expected: []optionVersion{
+				{Path: "master", Text: "Experimental", Name: "master", State: stateExperimental, Selected: false},
+				{Path: "", Text: "v2.0 Latest", Name: "v2.0", State: stateLatest, Selected: false},
+				{Path: "v1.7", Text: "v1.7", Name: "v1.7", State: "", Selected: false},
+				{Path: "v3.x-beta-docs", Text: "v3.x-beta-docs", Name: "v3.x-beta-docs", State: "", Selected: false},
+				{Path: "v2.x-legacy", Text: "v2.x-legacy", Name: "v2.x-legacy", State: "", Selected: false},
+				{Path: "docs10", Text: "docs10", Name: "docs10", State: "", Selected: false},
+				{Path: "docs9", Text: "docs9", Name: "docs9", State: "", Selected: true},
+			},
+		},
+		{
+			desc:           "pre-release branch",
+			branches:       []string{"origin/docs", "origin/v2.9", "origin/v3.0-rc", "origin/v2.8"},
+			latestTagName:  "v2.9.1",
+			currentVersion: "v2.9",
+			expected: []optionVersion{
+				{Path: "v3.0-rc", Text: "v3.0-rc RC", Name: "v3.0-rc", State: statePreFinalRelease, Selected: false},
+				{Path: "", Text: "v2.9 Latest", Name: "v2.9", State: stateLatest, Selected: true},
+				{Path: "v2.8", Text: "v2.8", Name: "v2.8", State: stateObsolete, Selected: false},
+				{Path: "docs", Text: "docs", Name: "docs", State: "", Selected: false},
+			},
+		},
+		{
+			desc:           "non-semver versions with settings",
+			branches:       []string{"origin/v2.x-legacy", "origin/v1.7", "origin/docs10", "origin/docs9"},
+			latestTagName:  "v1.7.0",
+			currentVersion: "v1.7",
+			nonSemver: &types.NonSemver{
+				State: "obsolete",
+				Sort:  "explicit",
+				Order: []string{"v2.x-legacy"},
+				Versions: []types.NonSemverVersion{
+					{Name: "v2.x-legacy", Label: "v2 (legacy)", State: "lts"},
+				},
+			},
+			expected: []optionVersion{
+				{Path: "", Text: "v1.7 Latest", Name: "v1.7", State: stateLatest, Selected: true},
+				{Path: "v2.x-legacy", Text: "v2 (legacy)", Name: "v2.x-legacy", State: stateLTS, Selected: false},
+				{Path: "docs10", Text: "docs10", Name: "docs10", State: stateObsolete, Selected: false},
+				{Path: "docs9", Text: "docs9", Name: "docs9", State: stateObsolete, Selected: false},
+			},
+		},
+		{
+			desc:                   "extra versions",
+			branches:               []string{"origin/v2.1", "origin/master", "origin/v2.0"},
+			latestTagName:          "v2.1.0",
+			experimentalBranchName: "master",
+			currentVersion:         "v2.1",
+			extra: []types.ExtraVersion{
+				{Name: "v1.7", URL: "https://v1.doc.traefik.io/traefik/", State: "eol", Label: "v1.7 (archive)"},
+				{Name: "v1.6", Archive: "https://example.com/v1.6.tar.gz", Path: "archives/v1.6"},
+				{Name: "v2.0", URL: "https://v2.doc.traefik.io/traefik/"},
+				{Name: "legacy", Archive: "./legacy.zip"},
+			},
+			expected: []optionVersion{
+				{Path: "master", Text: "Experimental", Name: "master", State: stateExperimental, Selected: false},
+				{Path: "", Text: "v2.1 Latest", Name: "v2.1", State: stateLatest, Selected: true},
+				{Path: "", Text: "v2.0", Name: "v2.0", State: stateObsolete, Selected: false, URL: "https://v2.doc.traefik.io/traefik/"},
+				{Path: "", Text: "v1.7 (archive)", Name: "v1.7", State: stateEOL, Selected: false, URL: "https://v1.doc.traefik.io/traefik/"},
+				{Path: "archives/v1.6", Text: "v1.6", Name: "v1.6", State: stateObsolete, Selected: false},
+				{Path: "legacy", Text: "legacy", Name: "legacy", State: "", Selected: false},
+			},
+		},
+		{
+			desc:                   "experimental branches",
+			branches:               []string{"origin/v2.1", "origin/master", "origin/feature/gateway-api", "origin/feature/hidden", "v2.1.0"},
+			latestTagName:          "v2.1.0",
+			experimentalBranchName: "master",
+			currentVersion:         "v2.1",
+			experimental: []types.ExperimentalBranch{
+				{Name: "master", Label: "Next"},
+				{Name: "feature/gateway-api", Path: "/preview/gateway-api/", Label: "Gateway API (preview)"},
+				{Name: "feature/hidden", Hidden: true},
+			},
+			expected: []optionVersion{
+				{Path: "master", Text: "Next", Name: "master", State: stateExperimental, Selected: false},
+				{Path: "preview/gateway-api", Text: "Gateway API (preview)", Name: "feature/gateway-api", State: stateExperimental, Selected: false},
+				{Path: "", Text: "v2.1 Latest", Name: "v2.1", State: stateLatest, Selected: true},
+			},
+		},
+		{
+			desc:           "current hidden experimental branch",
+			branches:       []string{"origin/v2.1", "origin/feature/hidden", "v2.1.0"},
+			latestTagName:  "v2.1.0",
+			currentVersion: "feature/hidden",
+			experimental: []types.ExperimentalBranch{
+				{Name: "feature/hidden", Hidden: true},
+			},
+			expected: []optionVersion{
+				{Path: "feature/hidden", Text: "Experimental", Name: "feature/hidden", State: stateExperimental, Selected: true},
+				{Path: "", Text: "v2.1 Latest", Name: "v2.1", State: stateLatest, Selected: false},
+			},
+		},
 	}
 
 	for _, test := range testCases {
@@ -230,7 +377,14 @@ func Test_buildVersions(t *testing.T) {
 		t.Run(test.desc, func(t *testing.T) {
 			t.Parallel()
 
-			versions, err := buildVersions(test.currentVersion, test.branches, test.latestTagName, test.experimentalBranchName)
+			versionsInfo := types.VersionsInformation{
+				Current:      test.currentVersion,
+				Latest:       test.latestTagName,
+				Experimental: test.experimentalBranchName,
+				Settings:     &types.Settings{Lifecycle: test.lifecycle, NonSemver: test.nonSemver, Extra: test.extra, Experimental: test.experimental},
+			}
+
+			versions, err := buildVersions(versionsInfo, test.branches)
 			require.NoError(t, err)
 
 			assert.Equal(t, test.expected, versions)
@@ -238,6 +392,40 @@ func Test_buildVersions(t *testing.T) {
 	}
 }
 
+func Test_completeVersions(t *testing.T) {
+	date := time.Date(2023, time.February, 14, 10, 11, 12, 0, time.UTC)
+
+	versionsInfo := types.VersionsInformation{
+		Experimental: "master",
+		Commits: map[string]types.CommitInformation{
+			"master": {SHA: "aaa", Date: date},
+			"v2.1":   {SHA: "bbb", Date: date},
+			"v1.4":   {SHA: "ccc", Date: date},
+		},
+		Tags: []string{"v1.4.0", "v1.4.10", "v1.4.9", "v2.0.0", "v2.1.0-rc1", "foo"},
+	}
+
+	versions := []optionVersion{
+		{Name: "master", State: stateExperimental},
+		{Name: "v2.1", State: statePreFinalRelease},
+		{Name: "v2.0", State: stateLatest},
+		{Name: "v1.4"},
+		{Name: "v1.3", State: stateObsolete},
+	}
+
+	completeVersions(versions, versionsInfo)
+
+	expected := []optionVersion{
+		{Name: "master", State: stateExperimental, Commit: "aaa", Date: date, Aliases: []string{"experimental"}},
+		{Name: "v2.1", State: statePreFinalRelease, Commit: "bbb", Date: date, Tag: "v2.1.0-rc1", NewestOfMajor: true},
+		{Name: "v2.0", State: stateLatest, Tag: "v2.0.0", Aliases: []string{"latest"}},
+		{Name: "v1.4", Commit: "ccc", Date: date, Tag: "v1.4.10", NewestOfMajor: true},
+		{Name: "v1.3", State: stateObsolete},
+	}
+
+	assert.Equal(t, expected, versions)
+}
+
 func mustReadFile(path string) []byte {
 	bytes, err := os.ReadFile(path)
 	if err != nil {
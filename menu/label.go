@@ -0,0 +1,154 @@
+package menu
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/traefik/structor/types"
+)
+
+// getDefaultStateLabels gets the labels used when no label is defined for the state of a version.
+func getDefaultStateLabels() map[string]string {
+	return map[string]string{
+		stateExperimental:    "Experimental",
+		statePreFinalRelease: "{{ .Name }} RC",
+		stateLatest:          "{{ .Name }} Latest",
+	}
+}
+
+// setLabels sets the display labels of the versions, and their translations.
+func setLabels(versions []optionVersion, settings *types.Settings) error {
+	config := settings.GetLabels()
+	if config == nil {
+		config = &types.Labels{}
+	}
+
+	versionLabels := getVersionLabels(settings)
+
+	stateLabels := getDefaultStateLabels()
+	for state, label := range config.States {
+		stateLabels[strings.ToUpper(state)] = label
+	}
+
+	for i, v := range versions {
+		text, err := getLabel(v, versionLabels, stateLabels, "{{ .Name }}")
+		if err != nil {
+			return err
+		}
+
+		versions[i].Text = text
+
+		for locale, translation := range config.Translations {
+			label, err := getLabel(versions[i], translation.Versions, upperKeys(translation.States), text)
+			if err != nil {
+				return fmt.Errorf("locale %s: %w", locale, err)
+			}
+
+			if versions[i].Labels == nil {
+				versions[i].Labels = map[string]string{}
+			}
+
+			versions[i].Labels[locale] = label
+		}
+	}
+
+	return nil
+}
+
+// getVersionLabels gets the labels of the versions defined by the settings, by version name.
+func getVersionLabels(settings *types.Settings) map[string]string {
+	versionLabels := map[string]string{}
+	if nonSemver := settings.GetNonSemver(); nonSemver != nil {
+		for _, v := range nonSemver.Versions {
+			if v.Label != "" {
+				versionLabels[v.Name] = v.Label
+			}
+		}
+	}
+
+	for _, e := range settings.GetExperimental() {
+		if e.Label != "" {
+			versionLabels[e.Name] = e.Label
+		}
+	}
+
+	for _, e := range settings.GetExtra() {
+		if e.Label != "" {
+			versionLabels[e.Name] = e.Label
+		}
+	}
+
+	if labels := settings.GetLabels(); labels != nil {
+		for name, label := range labels.Versions {
+			versionLabels[name] = label
+		}
+	}
+
+	return versionLabels
+}
+
+func getLabel(v optionVersion, versionLabels, stateLabels map[string]string, fallback string) (string, error) {
+	label, ok := versionLabels[v.Name]
+	if !ok {
+		label, ok = stateLabels[v.State]
+	}
+
+	if !ok {
+		label = fallback
+	}
+
+	tmpl, err := template.New(v.Name).Parse(label)
+	if err != nil {
+		return "", fmt.Errorf("invalid label %q: %w", label, err)
+	}
+
+	b := &strings.Builder{}
+
+	err = tmpl.Execute(b, v)
+	if err != nil {
+		return "", fmt.Errorf("invalid label %q: %w", label, err)
+	}
+
+	return b.String(), nil
+}
+
+func upperKeys(values map[string]string) map[string]string {
+	result := map[string]string{}
+	for key, value := range values {
+		result[strings.ToUpper(key)] = value
+	}
+
+	return result
+}
+
+// getLocale gets the locale of the theme defined in the manifest.
+func getLocale(manif map[string]interface{}) string {
+	theme, ok := manif["theme"].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+
+	if locale, ok := theme["locale"].(string); ok && locale != "" {
+		return locale
+	}
+
+	language, _ := theme["language"].(string)
+
+	return language
+}
+
+// translateLabel gets the label of a version for a locale (ex: "zh-CN", then "zh"), or its default label.
+func translateLabel(v optionVersion, locale string) string {
+	if label, ok := v.Labels[locale]; ok {
+		return label
+	}
+
+	if i := strings.IndexAny(locale, "-_"); i > 0 {
+		if label, ok := v.Labels[locale[:i]]; ok {
+			return label
+		}
+	}
+
+	return v.Text
+}
@@ -0,0 +1,124 @@
+package menu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/structor/types"
+)
+
+func Test_setLabels(t *testing.T) {
+	settings := &types.Settings{
+		NonSemver: &types.NonSemver{
+			Versions: []types.NonSemverVersion{{Name: "v2.x-legacy", Label: "v2 (legacy)"}},
+		},
+		Labels: &types.Labels{
+			LabelSet: types.LabelSet{
+				States:   map[string]string{"experimental": "Next", "LTS": "{{ .Name }} LTS"},
+				Versions: map[string]string{"v3.0": "{{ .Name }} (beta)"},
+			},
+			Translations: map[string]types.LabelSet{
+				"zh": {
+					States:   map[string]string{"EXPERIMENTAL": "下一个", "LATEST": "{{ .Name }} 最新"},
+					Versions: map[string]string{"v2.x-legacy": "v2 (旧版)"},
+				},
+			},
+		},
+	}
+
+	versions := []optionVersion{
+		{Name: "master", State: stateExperimental},
+		{Name: "v3.0", State: statePreFinalRelease},
+		{Name: "v2.11", State: stateLatest},
+		{Name: "v2.10", State: stateLTS},
+		{Name: "v2.9", State: stateObsolete},
+		{Name: "v2.x-legacy"},
+	}
+
+	err := setLabels(versions, settings)
+	require.NoError(t, err)
+
+	expected := []optionVersion{
+		{Name: "master", State: stateExperimental, Text: "Next", Labels: map[string]string{"zh": "下一个"}},
+		{Name: "v3.0", State: statePreFinalRelease, Text: "v3.0 (beta)", Labels: map[string]string{"zh": "v3.0 (beta)"}},
+		{Name: "v2.11", State: stateLatest, Text: "v2.11 Latest", Labels: map[string]string{"zh": "v2.11 最新"}},
+		{Name: "v2.10", State: stateLTS, Text: "v2.10 LTS", Labels: map[string]string{"zh": "v2.10 LTS"}},
+		{Name: "v2.9", State: stateObsolete, Text: "v2.9", Labels: map[string]string{"zh": "v2.9"}},
+		{Name: "v2.x-legacy", Text: "v2 (legacy)", Labels: map[string]string{"zh": "v2 (旧版)"}},
+	}
+
+	assert.Equal(t, expected, versions)
+}
+
+func Test_setLabels_error(t *testing.T) {
+	settings := &types.Settings{
+		Labels: &types.Labels{
+			LabelSet: types.LabelSet{States: map[string]string{"LATEST": "{{ .Name "}},
+		},
+	}
+
+	err := setLabels([]optionVersion{{Name: "v1.0", State: stateLatest}}, settings)
+	assert.Error(t, err)
+}
+
+func Test_translateLabel(t *testing.T) {
+	v := optionVersion{Text: "Experimental", Labels: map[string]string{"zh": "下一个"}}
+
+	testCases := []struct {
+		locale   string
+		expected string
+	}{
+		{locale: "", expected: "Experimental"},
+		{locale: "zh", expected: "下一个"},
+		{locale: "zh-CN", expected: "下一个"},
+		{locale: "fr", expected: "Experimental"},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.locale, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, translateLabel(v, test.locale))
+		})
+	}
+}
+
+func Test_getLocale(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		manif    map[string]interface{}
+		expected string
+	}{
+		{
+			desc:     "no theme",
+			manif:    map[string]interface{}{},
+			expected: "",
+		},
+		{
+			desc:     "theme name only",
+			manif:    map[string]interface{}{"theme": "material"},
+			expected: "",
+		},
+		{
+			desc:     "locale",
+			manif:    map[string]interface{}{"theme": map[string]interface{}{"name": "mkdocs", "locale": "zh_CN"}},
+			expected: "zh_CN",
+		},
+		{
+			desc:     "language",
+			manif:    map[string]interface{}{"theme": map[string]interface{}{"name": "material", "language": "zh"}},
+			expected: "zh",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, getLocale(test.manif))
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package menu
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-version"
+	"github.com/traefik/structor/types"
+)
+
+type lifecycleRule struct {
+	selector versionSelector
+	state    string
+	eol      time.Time
+}
+
+// lifecycle computes the states of the versions which are neither the latest, a pre-final release, nor experimental.
+type lifecycle struct {
+	rules []lifecycleRule
+	// supported the supported minors, by major.
+	supported map[int]map[int]struct{}
+	now       time.Time
+}
+
+func newLifecycle(config *types.Lifecycle, rawVersions []string, latestVersion *version.Version, now time.Time) (*lifecycle, error) {
+	keepMinors := 1
+	var rules []lifecycleRule
+
+	if config != nil {
+		if config.KeepMinors > 0 {
+			keepMinors = config.KeepMinors
+		}
+
+		for _, r := range config.Rules {
+			rule, err := newLifecycleRule(r)
+			if err != nil {
+				return nil, err
+			}
+
+			rules = append(rules, rule)
+		}
+	}
+
+	return &lifecycle{
+		rules:     rules,
+		supported: getSupportedMinors(rawVersions, latestVersion, keepMinors),
+		now:       now,
+	}, nil
+}
+
+func newLifecycleRule(config types.LifecycleRule) (lifecycleRule, error) {
+	state, err := parseLifecycleState(config.State)
+	if err != nil {
+		return lifecycleRule{}, err
+	}
+
+	selector, err := newVersionSelector(config.Versions)
+	if err != nil {
+		return lifecycleRule{}, fmt.Errorf("invalid lifecycle rule: %w", err)
+	}
+
+	return lifecycleRule{selector: selector, state: state, eol: config.EOL}, nil
+}
+
+func parseLifecycleState(state string) (string, error) {
+	switch strings.ToLower(state) {
+	case "", "supported":
+		return "", nil
+	case "lts":
+		return stateLTS, nil
+	case "obsolete":
+		return stateObsolete, nil
+	case "eol":
+		return stateEOL, nil
+	default:
+		return "", fmt.Errorf("invalid lifecycle state: %s", state)
+	}
+}
+
+// getSupportedMinors gets the most recent released minors of each major.
+// The versions newer than the latest version are ignored, when the latest version is defined.
+func getSupportedMinors(rawVersions []string, latestVersion *version.Version, keepMinors int) map[int]map[int]struct{} {
+	minors := map[int][]int{}
+	known := map[[2]int]struct{}{}
+
+	for _, versionName := range rawVersions {
+		v, err := parseVersion(versionName)
+		if err != nil || (latestVersion != nil && v.GreaterThan(latestVersion)) {
+			continue
+		}
+
+		segments := v.Segments()
+		key := [2]int{segments[0], segments[1]}
+		if _, ok := known[key]; ok {
+			continue
+		}
+		known[key] = struct{}{}
+
+		minors[segments[0]] = append(minors[segments[0]], segments[1])
+	}
+
+	supported := map[int]map[int]struct{}{}
+	for major, values := range minors {
+		sort.Sort(sort.Reverse(sort.IntSlice(values)))
+
+		supported[major] = map[int]struct{}{}
+		for i := 0; i < len(values) && i < keepMinors; i++ {
+			supported[major][values[i]] = struct{}{}
+		}
+	}
+
+	return supported
+}
+
+// getState returns the state and the end of life date of a version.
+func (l *lifecycle) getState(versionName string, v *version.Version) (string, time.Time) {
+	for _, rule := range l.rules {
+		if !rule.selector.match(versionName, v) {
+			continue
+		}
+
+		if !rule.eol.IsZero() && !l.now.Before(rule.eol) {
+			return stateEOL, rule.eol
+		}
+
+		return rule.state, rule.eol
+	}
+
+	segments := v.Segments()
+	if _, ok := l.supported[segments[0]][segments[1]]; ok {
+		return "", time.Time{}
+	}
+
+	return stateObsolete, time.Time{}
+}
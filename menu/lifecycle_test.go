@@ -0,0 +1,78 @@
+package menu
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/structor/types"
+)
+
+func Test_lifecycle_getState(t *testing.T) {
+	now := time.Date(2023, time.June, 1, 0, 0, 0, 0, time.UTC)
+	rawVersions := []string{"v3.0", "v2.9", "v2.8", "v2.7", "v1.7", "v1.6"}
+
+	config := &types.Lifecycle{
+		KeepMinors: 2,
+		Rules: []types.LifecycleRule{
+			{Versions: "v2.7", State: "LTS", EOL: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)},
+			{Versions: ">= 1.0, < 2.0", State: "lts", EOL: time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		},
+	}
+
+	lc, err := newLifecycle(config, rawVersions, version.Must(version.NewVersion("v2.9.0")), now)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		versionName   string
+		expectedState string
+		expectedEOL   time.Time
+	}{
+		{versionName: "v2.8", expectedState: ""},
+		{versionName: "v2.7", expectedState: stateLTS, expectedEOL: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{versionName: "v1.7", expectedState: stateEOL, expectedEOL: time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{versionName: "v1.6", expectedState: stateEOL, expectedEOL: time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.versionName, func(t *testing.T) {
+			t.Parallel()
+
+			state, eol := lc.getState(test.versionName, version.Must(version.NewVersion(test.versionName)))
+
+			assert.Equal(t, test.expectedState, state)
+			assert.Equal(t, test.expectedEOL, eol)
+		})
+	}
+}
+
+func Test_newLifecycle_error(t *testing.T) {
+	testCases := []struct {
+		desc string
+		rule types.LifecycleRule
+	}{
+		{
+			desc: "invalid state",
+			rule: types.LifecycleRule{Versions: "v1.0", State: "foo"},
+		},
+		{
+			desc: "invalid versions",
+			rule: types.LifecycleRule{Versions: ">= foo", State: "lts"},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			config := &types.Lifecycle{Rules: []types.LifecycleRule{test.rule}}
+
+			_, err := newLifecycle(config, nil, version.Must(version.NewVersion("v1.0.0")), time.Now())
+			assert.Error(t, err)
+		})
+	}
+}
@@ -1,9 +1,16 @@
 package menu
 
 import (
+	"path"
+
 	"github.com/traefik/structor/manifest"
+	"github.com/traefik/structor/types"
 )
 
+// metadataKey the key of the versions metadata in the "extra" attribute of the manifest file.
+// The metadata are available in the theme templates through "config.extra.structor".
+const metadataKey = "structor"
+
 func editManifest(manif map[string]interface{}, versionJsFile, versionCSSFile string) {
 	// Append menu JS file
 	manifest.AppendExtraJs(manif, versionJsFile)
@@ -14,3 +21,47 @@ func editManifest(manif map[string]interface{}, versionJsFile, versionCSSFile st
 	// reset site URL
 	manif["site_url"] = ""
 }
+
+func editManifestAssets(manif map[string]interface{}, assetFiles []string) {
+	for _, assetFile := range assetFiles {
+		switch path.Ext(assetFile) {
+		case ".js":
+			manifest.AppendExtraJs(manif, assetFile)
+		case ".css":
+			manifest.AppendExtraCSS(manif, assetFile)
+		}
+	}
+}
+
+func buildMetadata(versionsInfo types.VersionsInformation, versions []optionVersion) map[string]interface{} {
+	var state string
+	var entries []interface{}
+
+	for _, v := range versions {
+		if v.Name == versionsInfo.Current {
+			state = v.State
+		}
+
+		entry := map[string]interface{}{
+			"name":  v.Name,
+			"text":  v.Text,
+			"path":  v.Path,
+			"state": v.State,
+		}
+
+		if v.URL != "" {
+			entry["url"] = v.URL
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return map[string]interface{}{
+		"version":      versionsInfo.Current,
+		"latest":       versionsInfo.Latest,
+		"experimental": versionsInfo.Experimental,
+		"state":        state,
+		"versions":     entries,
+		"commit":       versionsInfo.Commits[versionsInfo.Current].SHA,
+	}
+}
@@ -15,13 +15,21 @@ const baseRemote = "origin/"
 
 // Content the content of menu files.
 type Content struct {
-	Js  []byte
-	CSS []byte
+	Js        []byte
+	CSS       []byte
+	Assets    []Asset
+	Overrides []Override
+	Theme     string
+	Static    string
 }
 
 // GetTemplateContent Gets menu template content.
 func GetTemplateContent(menu *types.MenuFiles) Content {
-	var content Content
+	if menu == nil {
+		return Content{}
+	}
+
+	content := Content{Static: menu.Static}
 
 	if menu.HasJsFile() {
 		jsContent, err := getMenuFileContent(menu.JsFile, menu.JsURL)
@@ -39,9 +47,47 @@ func GetTemplateContent(menu *types.MenuFiles) Content {
 		content.CSS = cssContent
 	}
 
+	if len(menu.Assets) > 0 {
+		assets, err := getAssets(menu.Assets)
+		if err != nil {
+			log.Println(err)
+			return Content{}
+		}
+		content.Assets = assets
+	}
+
+	if len(menu.Overrides) > 0 {
+		overrides, err := getOverrides(menu.Overrides)
+		if err != nil {
+			log.Println(err)
+			return Content{}
+		}
+		content.Overrides = overrides
+	}
+
+	if menu.Theme != "" {
+		if menu.Theme == ThemeAuto {
+			// the theme is resolved for each version.
+			content.Theme = ThemeAuto
+			return content
+		}
+
+		themeContent, err := content.withTheme(menu.Theme)
+		if err != nil {
+			log.Println(err)
+			return Content{}
+		}
+		content = themeContent
+	}
+
 	return content
 }
 
+// usesVersions checks if the menu content needs the versions: templates, static selector, or theme overrides.
+func (c Content) usesVersions() bool {
+	return len(c.Js) > 0 || len(c.CSS) > 0 || len(c.Assets) > 0 || c.Static != "" || len(c.Overrides) > 0
+}
+
 func getMenuFileContent(f, u string) ([]byte, error) {
 	if len(f) > 0 {
 		content, err := os.ReadFile(f)
@@ -67,22 +113,62 @@ func Build(versionsInfo types.VersionsInformation, branches []string, menuConten
 		return fmt.Errorf("failed to read manifest %s: %w", manifestFile, err)
 	}
 
-	manifestDocsDir := manifest.GetDocsDir(manif, manifestFile)
+	effective, err := manifest.ReadEffective(manifestFile)
+	if err != nil {
+		return fmt.Errorf("failed to resolve manifest %s: %w", manifestFile, err)
+	}
+
+	manifestDocsDir := manifest.GetDocsDir(effective, manifestFile)
 
 	log.Printf("Using docs_dir from manifest: %s", manifestDocsDir)
 
-	manifestJsFilePath, err := writeJsFile(manifestDocsDir, menuContent, versionsInfo, branches)
+	if menuContent.Theme == ThemeAuto {
+		menuContent, err = menuContent.withTheme(detectTheme(effective))
+		if err != nil {
+			return err
+		}
+	}
+
+	// without menu templates, the versions are only used by the metadata: an error is not fatal.
+	model, errModel := buildModel(versionsInfo, branches, effective)
+	if errModel != nil && menuContent.usesVersions() {
+		return errModel
+	}
+
+	manifestJsFilePath, err := writeJsFile(manifestDocsDir, menuContent, model)
 	if err != nil {
 		return err
 	}
 
-	manifestCSSFilePath, err := writeCSSFile(manifestDocsDir, menuContent)
+	manifestCSSFilePath, err := writeCSSFile(manifestDocsDir, menuContent, model)
 	if err != nil {
 		return err
 	}
 
+	manifestAssetFilePaths, err := writeAssets(manifestDocsDir, menuContent, model)
+	if err != nil {
+		return err
+	}
+
+	// the lists of a child manifest replace the lists of its parent.
+	manifest.ResolveInherited(manif, effective, "extra_javascript", "extra_css")
+
 	editManifest(manif, manifestJsFilePath, manifestCSSFilePath)
 
+	editManifestAssets(manif, manifestAssetFilePaths)
+
+	setMetadata(manif, versionsInfo, model, errModel)
+
+	err = buildStatic(manif, effective, manifestFile, menuContent.Static, model)
+	if err != nil {
+		return err
+	}
+
+	err = writeOverrides(manif, effective, manifestFile, menuContent.Overrides, model)
+	if err != nil {
+		return err
+	}
+
 	err = manifest.Write(manifestFile, manif)
 	if err != nil {
 		return fmt.Errorf("error when edit MkDocs manifest: %w", err)
@@ -90,3 +176,32 @@ func Build(versionsInfo types.VersionsInformation, branches []string, menuConten
 
 	return nil
 }
+
+// buildModel builds the model of the menu templates of the current version.
+func buildModel(versionsInfo types.VersionsInformation, branches []string, effective map[string]interface{}) (templateModel, error) {
+	versions, err := buildVersions(versionsInfo, branches)
+	if err != nil {
+		return templateModel{}, fmt.Errorf("error when build versions: %w", err)
+	}
+
+	completeVersions(versions, versionsInfo)
+
+	model := newTemplateModel(versionsInfo, versions, versionsInfo.SiteURL, getLocale(effective))
+
+	err = model.setBanner(versionsInfo.Settings.GetBanner())
+	if err != nil {
+		return templateModel{}, err
+	}
+
+	return model, nil
+}
+
+// setMetadata writes the metadata of the versions into the manifest, when the versions are built.
+func setMetadata(manif map[string]interface{}, versionsInfo types.VersionsInformation, model templateModel, errModel error) {
+	if errModel != nil {
+		log.Printf("[WARN] no versions metadata: %v", errModel)
+		return
+	}
+
+	manifest.SetExtra(manif, metadataKey, buildMetadata(versionsInfo, model.Versions))
+}
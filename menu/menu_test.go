@@ -90,6 +90,36 @@ func TestGetTemplateContent(t *testing.T) {
 			},
 			expected: Content{},
 		},
+		{
+			desc: "theme",
+			menuFiles: &types.MenuFiles{
+				Theme: "readthedocs",
+			},
+			expected: Content{
+				Js:  append(mustReadFile("./themes/common.js.gotmpl"), mustReadFile("./themes/readthedocs.js.gotmpl")...),
+				CSS: mustReadFile("./themes/readthedocs.css.gotmpl"),
+			},
+		},
+		{
+			desc: "theme with JS local file",
+			menuFiles: &types.MenuFiles{
+				JsFile: "./fixtures/test-menu.js.gotmpl",
+				Theme:  "readthedocs",
+			},
+			expected: Content{
+				Js:  mustReadFile("./fixtures/test-menu.js.gotmpl"),
+				CSS: mustReadFile("./themes/readthedocs.css.gotmpl"),
+			},
+		},
+		{
+			desc: "auto theme",
+			menuFiles: &types.MenuFiles{
+				Theme: "auto",
+			},
+			expected: Content{
+				Theme: "auto",
+			},
+		},
 	}
 
 	for _, test := range testCases {
@@ -130,3 +160,108 @@ func TestBuild(t *testing.T) {
 	assert.FileExists(t, filepath.Join(projectDir, "docs", "theme", "js", menuJsFileName))
 	assert.FileExists(t, filepath.Join(projectDir, "docs", "theme", "css", menuCSSFileName))
 }
+
+func TestBuild_withoutTemplates(t *testing.T) {
+	projectDir, err := os.MkdirTemp("", "structor-test")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(projectDir) }()
+
+	manifestFile := filepath.Join(projectDir, manifest.FileName)
+	err = file.Copy(filepath.Join(".", "fixtures", "mkdocs.yml"), manifestFile)
+	require.NoError(t, err)
+
+	versionsInfo := types.VersionsInformation{
+		Latest:      "latest-release",
+		CurrentPath: projectDir,
+	}
+
+	// the latest tag is not semver: the versions are not required without menu templates.
+	err = Build(versionsInfo, []string{"origin/v1.7"}, Content{})
+	require.NoError(t, err)
+
+	err = Build(versionsInfo, []string{"origin/v1.7"}, Content{Js: mustReadFile("./fixtures/test-menu.js.gotmpl")})
+	require.Error(t, err)
+}
+
+func TestBuild_inherit(t *testing.T) {
+	projectDir, err := os.MkdirTemp("", "structor-test")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(projectDir) }()
+
+	err = file.Copy(filepath.Join(".", "fixtures", "inherit", "base.yml"), filepath.Join(projectDir, "base.yml"))
+	require.NoError(t, err)
+
+	manifestFile := filepath.Join(projectDir, manifest.FileName)
+	err = file.Copy(filepath.Join(".", "fixtures", "inherit", "mkdocs.yml"), manifestFile)
+	require.NoError(t, err)
+
+	versionsInfo := types.VersionsInformation{
+		Current:     "v1.7",
+		Latest:      "v1.7.9",
+		CurrentPath: projectDir,
+		Commits: map[string]types.CommitInformation{
+			"v1.7": {SHA: "a1b2c3d"},
+		},
+	}
+
+	menuContent := Content{
+		Js: mustReadFile("./fixtures/test-menu.js.gotmpl"),
+	}
+
+	err = Build(versionsInfo, []string{"origin/v1.7"}, menuContent)
+	require.NoError(t, err)
+
+	assert.FileExists(t, filepath.Join(projectDir, "content", "theme", "js", menuJsFileName))
+	assertSameContent(t, filepath.Join(".", "fixtures", "inherit", "expected.yml"), manifestFile)
+}
+
+func Test_buildModel_siteURL(t *testing.T) {
+	testCases := []struct {
+		desc            string
+		siteURL         string
+		manifestSiteURL string
+		expectedSiteURL string
+		expectedURL     string
+	}{
+		{
+			desc:            "site URL",
+			siteURL:         "https://example.com/foo/",
+			manifestSiteURL: "https://doc.traefik.io/traefik/",
+			expectedSiteURL: "https://example.com/foo/",
+			expectedURL:     "https://example.com/foo/v1.7/",
+		},
+		{
+			desc:            "site URL of the manifest",
+			manifestSiteURL: "https://doc.traefik.io/traefik/",
+		},
+		{
+			desc: "no site URL",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			versionsInfo := types.VersionsInformation{
+				Current: "v1.7",
+				Latest:  "v2.0.0",
+				SiteURL: test.siteURL,
+			}
+
+			effective := map[string]interface{}{"site_url": test.manifestSiteURL}
+
+			model, err := buildModel(versionsInfo, []string{"origin/v1.7", "origin/v2.0"}, effective)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expectedSiteURL, model.SiteURL)
+
+			for _, v := range model.Versions {
+				if v.Name == "v1.7" {
+					assert.Equal(t, test.expectedURL, v.URL)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package menu
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/go-version"
+	"github.com/traefik/structor/types"
+)
+
+const (
+	nonSemverSortNatural  = "natural"
+	nonSemverSortDate     = "date"
+	nonSemverSortExplicit = "explicit"
+)
+
+// parseVersion parses a version name (branch name).
+// A name which can't be parsed (ex: "v2.x-legacy", "next") is not semver, a pre-release (ex: "v3.0-rc") is a version.
+func parseVersion(versionName string) (*version.Version, error) {
+	return version.NewVersion(versionName)
+}
+
+// buildNonSemverVersions builds the versions with a non-semver name.
+func buildNonSemverVersions(versionsInfo types.VersionsInformation, names []string) ([]optionVersion, error) {
+	config := versionsInfo.Settings.GetNonSemver()
+	if config == nil {
+		config = &types.NonSemver{}
+	}
+
+	defaultState, err := parseLifecycleState(config.State)
+	if err != nil {
+		return nil, err
+	}
+
+	err = sortNonSemver(names, config, versionsInfo.Commits)
+	if err != nil {
+		return nil, err
+	}
+
+	states := map[string]string{}
+	for _, v := range config.Versions {
+		states[v.Name] = v.State
+	}
+
+	var versions []optionVersion
+	for _, name := range names {
+		v := optionVersion{
+			Path:     name,
+			Name:     name,
+			State:    defaultState,
+			Selected: versionsInfo.Current == name,
+		}
+
+		if state := states[name]; state != "" {
+			v.State, err = parseLifecycleState(state)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		versions = append(versions, v)
+	}
+
+	return versions, nil
+}
+
+func sortNonSemver(names []string, config *types.NonSemver, commits map[string]types.CommitInformation) error {
+	switch strings.ToLower(config.Sort) {
+	case "", nonSemverSortNatural:
+		sort.SliceStable(names, func(i, j int) bool {
+			return naturalLess(names[j], names[i])
+		})
+
+	case nonSemverSortDate:
+		sort.SliceStable(names, func(i, j int) bool {
+			return commits[names[i]].Date.After(commits[names[j]].Date)
+		})
+
+	case nonSemverSortExplicit:
+		positions := map[string]int{}
+		for i, name := range config.Order {
+			positions[name] = i
+		}
+
+		sort.SliceStable(names, func(i, j int) bool {
+			pi, oki := positions[names[i]]
+			pj, okj := positions[names[j]]
+
+			switch {
+			case oki && okj:
+				return pi < pj
+			case oki != okj:
+				return oki
+			default:
+				return naturalLess(names[j], names[i])
+			}
+		})
+
+	default:
+		return fmt.Errorf("invalid non-semver sort: %s", config.Sort)
+	}
+
+	return nil
+}
+
+// naturalLess compares two strings, the sequences of digits are compared numerically (ex: "a2" < "a10").
+func naturalLess(a, b string) bool {
+	chunksA, chunksB := splitDigits(a), splitDigits(b)
+
+	for i := 0; i < len(chunksA) && i < len(chunksB); i++ {
+		if chunksA[i] == chunksB[i] {
+			continue
+		}
+
+		na, errA := strconv.Atoi(chunksA[i])
+		nb, errB := strconv.Atoi(chunksB[i])
+		if errA == nil && errB == nil && na != nb {
+			return na < nb
+		}
+
+		return chunksA[i] < chunksB[i]
+	}
+
+	return len(chunksA) < len(chunksB)
+}
+
+func splitDigits(value string) []string {
+	var chunks []string
+	var current []rune
+
+	for _, r := range value {
+		if len(current) > 0 && unicode.IsDigit(r) != unicode.IsDigit(current[len(current)-1]) {
+			chunks = append(chunks, string(current))
+			current = nil
+		}
+
+		current = append(current, r)
+	}
+
+	if len(current) > 0 {
+		chunks = append(chunks, string(current))
+	}
+
+	return chunks
+}
@@ -0,0 +1,60 @@
+package menu
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/structor/types"
+)
+
+func Test_sortNonSemver(t *testing.T) {
+	commits := map[string]types.CommitInformation{
+		"legacy":  {Date: time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		"next":    {Date: time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		"docs-2":  {Date: time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		"docs-10": {Date: time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	testCases := []struct {
+		desc     string
+		config   *types.NonSemver
+		expected []string
+	}{
+		{
+			desc:     "natural",
+			config:   &types.NonSemver{},
+			expected: []string{"next", "legacy", "docs-10", "docs-2"},
+		},
+		{
+			desc:     "date",
+			config:   &types.NonSemver{Sort: "date"},
+			expected: []string{"next", "docs-2", "legacy", "docs-10"},
+		},
+		{
+			desc:     "explicit",
+			config:   &types.NonSemver{Sort: "explicit", Order: []string{"docs-2", "legacy"}},
+			expected: []string{"docs-2", "legacy", "next", "docs-10"},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			names := []string{"legacy", "docs-2", "next", "docs-10"}
+
+			err := sortNonSemver(names, test.config, commits)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, names)
+		})
+	}
+}
+
+func Test_sortNonSemver_error(t *testing.T) {
+	err := sortNonSemver([]string{"legacy"}, &types.NonSemver{Sort: "foo"}, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,92 @@
+package menu
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// Override a templated theme override file (ex: "main.html", "partials/footer.html").
+type Override struct {
+	// Path the path of the file, relative to the custom directory of the theme.
+	Path    string
+	Content []byte
+}
+
+// getOverrides gets the overrides from their sources: "<path>=<file path or URL>", or "<file path or URL>".
+func getOverrides(sources []string) ([]Override, error) {
+	var overrides []Override
+
+	for _, src := range sources {
+		name, location, found := strings.Cut(src, "=")
+		if !found {
+			location = src
+			name = strings.TrimSuffix(path.Base(src), ".gotmpl")
+		}
+
+		name = path.Clean(filepath.ToSlash(name))
+		if path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+			return nil, fmt.Errorf("invalid theme override path: %s", name)
+		}
+
+		content, err := getAssetContent(location)
+		if err != nil {
+			return nil, err
+		}
+
+		overrides = append(overrides, Override{Path: name, Content: content})
+	}
+
+	return overrides, nil
+}
+
+// writeOverrides renders the overrides into the custom directory of the theme.
+// The overrides of the version are kept, except the files replaced by the overrides.
+func writeOverrides(manif, effective map[string]interface{}, manifestFile string, overrides []Override, model templateModel) error {
+	if len(overrides) == 0 {
+		return nil
+	}
+
+	customDir := getCustomDir(manif, effective, manifestFile)
+
+	for _, override := range overrides {
+		filePath := filepath.Join(customDir, filepath.FromSlash(override.Path))
+
+		if _, err := os.Stat(filePath); err == nil {
+			log.Printf("[WARN] the theme override %s of %s is replaced.", override.Path, model.Current)
+		}
+
+		err := os.MkdirAll(filepath.Dir(filePath), os.ModePerm)
+		if err != nil {
+			return fmt.Errorf("failed to create the theme custom directory: %w", err)
+		}
+
+		err = writeOverride(filePath, override, model)
+		if err != nil {
+			return fmt.Errorf("failed to write the theme override %s: %w", override.Path, err)
+		}
+	}
+
+	return nil
+}
+
+// writeOverride renders an override.
+// The delimiters are "[[" and "]]", because the override files are also templates of the theme (Jinja).
+func writeOverride(filePath string, override Override, model templateModel) error {
+	f, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	defer safeClose(f.Close)
+
+	temp, err := template.New(override.Path).Delims("[[", "]]").Funcs(templateFuncMap()).Parse(string(override.Content))
+	if err != nil {
+		return fmt.Errorf("error during parsing template: %w", err)
+	}
+
+	return temp.Execute(f, model)
+}
@@ -0,0 +1,116 @@
+package menu
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_getOverrides(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		sources  []string
+		expected []string
+	}{
+		{
+			desc:     "file name",
+			sources:  []string{"./fixtures/overrides/footer.html.gotmpl"},
+			expected: []string{"footer.html"},
+		},
+		{
+			desc:     "path",
+			sources:  []string{"partials/footer.html=./fixtures/overrides/footer.html.gotmpl"},
+			expected: []string{"partials/footer.html"},
+		},
+		{
+			desc:     "clean path",
+			sources:  []string{"./partials//footer.html=./fixtures/overrides/footer.html.gotmpl"},
+			expected: []string{"partials/footer.html"},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			overrides, err := getOverrides(test.sources)
+			require.NoError(t, err)
+
+			var paths []string
+			for _, override := range overrides {
+				paths = append(paths, override.Path)
+				assert.NotEmpty(t, override.Content)
+			}
+
+			assert.Equal(t, test.expected, paths)
+		})
+	}
+}
+
+func Test_getOverrides_invalid(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		sources []string
+	}{
+		{
+			desc:    "absolute path",
+			sources: []string{"/etc/footer.html=./fixtures/overrides/footer.html.gotmpl"},
+		},
+		{
+			desc:    "parent path",
+			sources: []string{"partials/../../footer.html=./fixtures/overrides/footer.html.gotmpl"},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := getOverrides(test.sources)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func Test_writeOverrides(t *testing.T) {
+	dir, err := os.MkdirTemp("", "structor-test")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	// the overrides of the version.
+	err = os.MkdirAll(filepath.Join(dir, "overrides", "partials"), os.ModePerm)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(dir, "overrides", "main.html"), []byte("main"), os.ModePerm)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(dir, "overrides", "partials", "footer.html"), []byte("footer"), os.ModePerm)
+	require.NoError(t, err)
+
+	overrides, err := getOverrides([]string{"partials/footer.html=./fixtures/overrides/footer.html.gotmpl"})
+	require.NoError(t, err)
+
+	manif := map[string]interface{}{"theme": map[string]interface{}{"name": "material", "custom_dir": "overrides"}}
+	model := templateModel{Current: "v2.0", Commit: "9a8b7c6d"}
+
+	err = writeOverrides(manif, manif, filepath.Join(dir, "mkdocs.yml"), overrides, model)
+	require.NoError(t, err)
+
+	main, err := os.ReadFile(filepath.Join(dir, "overrides", "main.html"))
+	require.NoError(t, err)
+	assert.Equal(t, "main", string(main))
+
+	footer, err := os.ReadFile(filepath.Join(dir, "overrides", "partials", "footer.html"))
+	require.NoError(t, err)
+
+	expected := `{% extends "base.html" %}
+{% block footer %}
+{{ super() }}
+<p class="structor-commit">v2.0 (9a8b7c6d)</p>
+{% endblock %}
+`
+	assert.Equal(t, expected, string(footer))
+}
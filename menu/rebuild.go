@@ -0,0 +1,39 @@
+package menu
+
+import (
+	"fmt"
+
+	"github.com/traefik/structor/types"
+)
+
+// Rebuild regenerates the menu files of a version already built, into its site directory (ex: "site/v2.9").
+// With the HTML injection, the menu files are also injected into the HTML files.
+// The elements rendered by MkDocs (the static menu, the theme overrides, and the metadata of the manifest) are not regenerated.
+func Rebuild(versionsInfo types.VersionsInformation, branches []string, menuContent Content, versionSiteDir string, injectHTML bool) error {
+	if menuContent.Theme == ThemeAuto {
+		return fmt.Errorf("the theme of the menu of %s can't be detected from its site", versionsInfo.Current)
+	}
+
+	if injectHTML {
+		return buildSite(versionsInfo, branches, menuContent, versionSiteDir, "")
+	}
+
+	model, err := buildModel(versionsInfo, branches, map[string]interface{}{})
+	if err != nil {
+		return err
+	}
+
+	_, err = writeJsFile(versionSiteDir, menuContent, model)
+	if err != nil {
+		return err
+	}
+
+	_, err = writeCSSFile(versionSiteDir, menuContent, model)
+	if err != nil {
+		return err
+	}
+
+	_, err = writeAssets(versionSiteDir, menuContent, model)
+
+	return err
+}
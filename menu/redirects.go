@@ -0,0 +1,73 @@
+package menu
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/traefik/structor/redirect"
+	"github.com/traefik/structor/types"
+)
+
+// GetRedirections gets the redirections of the rules in each version of the site matching the rules.
+func (i Inventory) GetRedirections(rules []types.RedirectRule) ([]redirect.Redirection, error) {
+	selectors := make([]*versionSelector, len(rules))
+	for idx, rule := range rules {
+		if rule.From == "" || rule.To == "" {
+			return nil, fmt.Errorf("invalid redirection %q to %q: the paths are required", rule.From, rule.To)
+		}
+
+		if rule.Versions == "" {
+			continue
+		}
+
+		selector, err := newVersionSelector(rule.Versions)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redirection from %s: %w", rule.From, err)
+		}
+
+		selectors[idx] = &selector
+	}
+
+	var redirections []redirect.Redirection
+	for _, v := range i.Versions {
+		if v.URL != "" {
+			// not in the site.
+			continue
+		}
+
+		for idx, rule := range rules {
+			if selectors[idx] != nil && !selectors[idx].matchName(v.Name) {
+				continue
+			}
+
+			for _, root := range getVersionRoots(v) {
+				redirections = append(redirections, redirect.Redirection{
+					From: joinPagePath(root, rule.From),
+					To:   joinPagePath(root, rule.To),
+				})
+			}
+		}
+	}
+
+	return redirections, nil
+}
+
+// getVersionRoots gets the paths of a version in the site: the latest version is at the root of the site, and under its name.
+func getVersionRoots(v InventoryVersion) []string {
+	if v.Path == "" {
+		return []string{"", v.Name}
+	}
+
+	return []string{v.Path}
+}
+
+// joinPagePath joins the path of a version and the path of a page, the trailing slash of the page is kept.
+func joinPagePath(root, page string) string {
+	p := strings.TrimPrefix(path.Join(root, page), "/")
+	if strings.HasSuffix(page, "/") && p != "" {
+		return p + "/"
+	}
+
+	return p
+}
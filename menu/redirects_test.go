@@ -0,0 +1,55 @@
+package menu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/structor/redirect"
+	"github.com/traefik/structor/types"
+)
+
+func TestInventory_GetRedirections(t *testing.T) {
+	inventory := Inventory{
+		Latest:       "v2.5.1",
+		Experimental: "master",
+		Versions: []InventoryVersion{
+			{Name: "master", Path: "master"},
+			{Name: "v2.5", Path: ""},
+			{Name: "v2.4", Path: "v2.4"},
+			{Name: "v2.3", Path: "v2.3"},
+			{Name: "v1.7", URL: "https://v1.doc.traefik.io/traefik/"},
+		},
+	}
+
+	rules := []types.RedirectRule{
+		{From: "basics/", To: "routing/overview/", Versions: ">= 2.4"},
+		{From: "old.html", To: "new/"},
+	}
+
+	redirections, err := inventory.GetRedirections(rules)
+	require.NoError(t, err)
+
+	expected := []redirect.Redirection{
+		{From: "master/old.html", To: "master/new/"},
+		{From: "basics/", To: "routing/overview/"},
+		{From: "v2.5/basics/", To: "v2.5/routing/overview/"},
+		{From: "old.html", To: "new/"},
+		{From: "v2.5/old.html", To: "v2.5/new/"},
+		{From: "v2.4/basics/", To: "v2.4/routing/overview/"},
+		{From: "v2.4/old.html", To: "v2.4/new/"},
+		{From: "v2.3/old.html", To: "v2.3/new/"},
+	}
+
+	assert.Equal(t, expected, redirections)
+}
+
+func TestInventory_GetRedirections_error(t *testing.T) {
+	inventory := Inventory{Versions: []InventoryVersion{{Name: "v2.4", Path: "v2.4"}}}
+
+	_, err := inventory.GetRedirections([]types.RedirectRule{{From: "basics/"}})
+	assert.Error(t, err)
+
+	_, err = inventory.GetRedirections([]types.RedirectRule{{From: "basics/", To: "routing/", Versions: ">= "}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,91 @@
+package menu
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+type stateDescription struct {
+	Name        string
+	Description string
+}
+
+// getStates returns the states of the versions, as exposed to the menu templates.
+func getStates() []stateDescription {
+	return []stateDescription{
+		{Name: stateLatest, Description: "The version matching the latest release tag, served at the root of the site."},
+		{Name: stateExperimental, Description: "The experimental branch."},
+		{Name: statePreFinalRelease, Description: "A version newer than the latest release (release candidate)."},
+		{Name: stateObsolete, Description: "A version which is not supported anymore (by default, not one of the newest minors of its major)."},
+		{Name: stateLTS, Description: "A version with a long term support (lifecycle policy)."},
+		{Name: stateEOL, Description: "A version which has reached its end of life (lifecycle policy)."},
+		{Name: "", Description: "A supported version."},
+	}
+}
+
+// WriteModelReference writes the reference of the model of the menu templates, in Markdown.
+func WriteModelReference(w io.Writer) error {
+	b := &strings.Builder{}
+
+	b.WriteString("# Menu Templates Reference\n\n")
+	b.WriteString("<!-- Generated by `structor doc`. DO NOT EDIT. -->\n\n")
+	b.WriteString("The menu templates (JS, CSS, and assets) are [Go templates](https://pkg.go.dev/text/template).\n")
+	b.WriteString("The fields of the model and the functions described below are a stable contract for the template authors.\n\n")
+
+	b.WriteString("## Model\n\n")
+	writeFields(b, reflect.TypeOf(templateModel{}))
+
+	b.WriteString("\n## Version\n\n")
+	b.WriteString("The items of `.Versions`.\n\n")
+	writeFields(b, reflect.TypeOf(optionVersion{}))
+
+	b.WriteString("\n## States\n\n")
+	b.WriteString("| State | Description |\n")
+	b.WriteString("|-------|-------------|\n")
+	for _, state := range getStates() {
+		name := "(empty)"
+		if state.Name != "" {
+			name = "`" + state.Name + "`"
+		}
+		fmt.Fprintf(b, "| %s | %s |\n", name, state.Description)
+	}
+
+	b.WriteString("\n## Functions\n\n")
+	b.WriteString("The [sprig](http://masterminds.github.io/sprig/) functions are available, and the following functions:\n\n")
+	b.WriteString("| Function | Usage | Description |\n")
+	b.WriteString("|----------|-------|-------------|\n")
+	for _, fn := range customTemplateFuncs() {
+		fmt.Fprintf(b, "| `%s` | `{{ %s }}` | %s |\n", fn.Name, fn.Usage, fn.Description)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeFields(b *strings.Builder, typ reflect.Type) {
+	b.WriteString("| Field | Type | Description |\n")
+	b.WriteString("|-------|------|-------------|\n")
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		fmt.Fprintf(b, "| `.%s` | `%s` | %s |\n", field.Name, typeName(field.Type), field.Tag.Get("description"))
+	}
+}
+
+func typeName(typ reflect.Type) string {
+	switch typ.Kind() {
+	case reflect.Slice:
+		return "[]" + typeName(typ.Elem())
+	case reflect.Map:
+		return "map[" + typeName(typ.Key()) + "]" + typeName(typ.Elem())
+	case reflect.Struct:
+		if typ == reflect.TypeOf(optionVersion{}) {
+			return "Version"
+		}
+		return typ.String()
+	default:
+		return typ.String()
+	}
+}
@@ -0,0 +1,23 @@
+package menu
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteModelReference(t *testing.T) {
+	b := &bytes.Buffer{}
+
+	err := WriteModelReference(b)
+	require.NoError(t, err)
+
+	expected, err := os.ReadFile(filepath.Join("..", "docs", "menu-templates.md"))
+	require.NoError(t, err)
+
+	assert.Equal(t, string(expected), b.String(), "the reference must be regenerated with 'structor doc'")
+}
@@ -0,0 +1,149 @@
+package menu
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/traefik/structor/repository"
+	"github.com/traefik/structor/types"
+)
+
+// Render Renders a menu template outside of a documentation build.
+// The output is written into the output file, or into stdout if the output file is not defined.
+// With the option "All", the template is rendered for each version, and the output is a directory.
+func Render(config *types.RenderConfiguration, stdout io.Writer) error {
+	content, err := os.ReadFile(config.TemplateFile)
+	if err != nil {
+		return fmt.Errorf("failed to read the template: %w", err)
+	}
+
+	branches, err := getRenderBranches(config)
+	if err != nil {
+		return err
+	}
+
+	name := filepath.Base(config.TemplateFile)
+	outputName := strings.TrimSuffix(name, ".gotmpl")
+
+	if !config.All {
+		return renderVersion(config, branches, config.Current, name, string(content), config.Output, stdout)
+	}
+
+	for _, branch := range branches {
+		versionName := strings.Replace(branch, baseRemote, "", 1)
+
+		var output string
+		if config.Output != "" {
+			output = filepath.Join(config.Output, versionName, outputName)
+		} else {
+			_, _ = fmt.Fprintf(stdout, "\n// ---- %s ----\n\n", versionName)
+		}
+
+		err = renderVersion(config, branches, versionName, name, string(content), output, stdout)
+		if err != nil {
+			return fmt.Errorf("version %s: %w", versionName, err)
+		}
+	}
+
+	return nil
+}
+
+func getRenderBranches(config *types.RenderConfiguration) ([]string, error) {
+	if len(config.Versions) > 0 {
+		return config.Versions, nil
+	}
+
+	var branches []string
+
+	if config.ExperimentalBranchName != "" {
+		branches = append(branches, baseRemote+config.ExperimentalBranchName)
+	}
+
+	for _, e := range config.Settings.GetExperimental() {
+		if e.Name != config.ExperimentalBranchName {
+			branches = append(branches, baseRemote+e.Name)
+		}
+	}
+
+	gitBranches, err := repository.ListBranches(config.Debug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branches: %w", err)
+	}
+
+	return append(branches, gitBranches...), nil
+}
+
+func renderVersion(config *types.RenderConfiguration, branches []string, current, name, content, output string, stdout io.Writer) error {
+	versionsInfo := types.VersionsInformation{
+		Owner:        config.Owner,
+		Repository:   config.RepositoryName,
+		Current:      current,
+		Latest:       config.Latest,
+		Experimental: config.ExperimentalBranchName,
+		SiteURL:      config.SiteURL,
+		BasePath:     config.BasePath,
+		Settings:     config.Settings,
+	}
+
+	versions, err := buildVersions(versionsInfo, branches)
+	if err != nil {
+		return fmt.Errorf("error when build versions: %w", err)
+	}
+
+	completeVersions(versions, versionsInfo)
+
+	model := newTemplateModel(versionsInfo, versions, config.SiteURL, config.Locale)
+
+	err = model.setBanner(versionsInfo.Settings.GetBanner())
+	if err != nil {
+		return err
+	}
+
+	b := &bytes.Buffer{}
+
+	err = renderTemplate(b, name, content, model)
+	if err != nil {
+		return describeTemplateError(err, content)
+	}
+
+	if output == "" {
+		_, err = stdout.Write(b.Bytes())
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(output), os.ModePerm)
+	if err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	return os.WriteFile(output, b.Bytes(), os.ModePerm)
+}
+
+// describeTemplateError adds the line of the template related to the error.
+func describeTemplateError(err error, content string) error {
+	exp := regexp.MustCompile(`^template: [^:]+:(\d+)(?::\d+)?:`)
+
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		submatch := exp.FindStringSubmatch(e.Error())
+		if len(submatch) != 2 {
+			continue
+		}
+
+		lineNumber, errConv := strconv.Atoi(submatch[1])
+		lines := strings.Split(content, "\n")
+		if errConv != nil || lineNumber < 1 || lineNumber > len(lines) {
+			break
+		}
+
+		return fmt.Errorf("%w\n%5d | %s", err, lineNumber, lines[lineNumber-1])
+	}
+
+	return err
+}
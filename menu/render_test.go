@@ -0,0 +1,103 @@
+package menu
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/structor/types"
+)
+
+func TestRender(t *testing.T) {
+	dir, err := os.MkdirTemp("", "structor-test")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	templateFile := filepath.Join(dir, "menu.js.gotmpl")
+	err = os.WriteFile(templateFile, []byte(`{{ .Current }}:{{ range .Versions }} {{ .Text }}{{ end }}`), os.ModePerm)
+	require.NoError(t, err)
+
+	config := &types.RenderConfiguration{
+		TemplateFile:           templateFile,
+		Versions:               []string{"v1.9", "master", "v1.10"},
+		Current:                "v1.10",
+		Latest:                 "v1.9.6",
+		ExperimentalBranchName: "master",
+	}
+
+	b := &bytes.Buffer{}
+
+	err = Render(config, b)
+	require.NoError(t, err)
+
+	assert.Equal(t, "v1.10: Experimental v1.10 RC v1.9 Latest", b.String())
+}
+
+func TestRender_all(t *testing.T) {
+	dir, err := os.MkdirTemp("", "structor-test")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	templateFile := filepath.Join(dir, "menu.js.gotmpl")
+	err = os.WriteFile(templateFile, []byte(`{{ .Current }}`), os.ModePerm)
+	require.NoError(t, err)
+
+	config := &types.RenderConfiguration{
+		TemplateFile: templateFile,
+		Versions:     []string{"v1.9", "v1.8"},
+		Latest:       "v1.9.6",
+		All:          true,
+		Output:       filepath.Join(dir, "output"),
+	}
+
+	err = Render(config, &bytes.Buffer{})
+	require.NoError(t, err)
+
+	assertFileContent(t, "v1.9", filepath.Join(dir, "output", "v1.9", "menu.js"))
+	assertFileContent(t, "v1.8", filepath.Join(dir, "output", "v1.8", "menu.js"))
+}
+
+func TestRender_error(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		template string
+		expected string
+	}{
+		{
+			desc:     "parsing error",
+			template: "var a;\n{{ if }\n",
+			expected: "error during parsing template: template: menu.js.gotmpl:2: unexpected \"}\" in if\n    2 | {{ if }",
+		},
+		{
+			desc:     "execution error",
+			template: "var a;\n\nvar b = {{ .Foo }};\n",
+			expected: "template: menu.js.gotmpl:3:11: executing \"menu.js.gotmpl\" at <.Foo>: can't evaluate field Foo in type menu.templateModel\n    3 | var b = {{ .Foo }};",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			dir, err := os.MkdirTemp("", "structor-test")
+			require.NoError(t, err)
+			defer func() { _ = os.RemoveAll(dir) }()
+
+			templateFile := filepath.Join(dir, "menu.js.gotmpl")
+			err = os.WriteFile(templateFile, []byte(test.template), os.ModePerm)
+			require.NoError(t, err)
+
+			config := &types.RenderConfiguration{
+				TemplateFile: templateFile,
+				Versions:     []string{"v1.9"},
+				Current:      "v1.9",
+				Latest:       "v1.9.6",
+			}
+
+			err = Render(config, &bytes.Buffer{})
+			assert.EqualError(t, err, test.expected)
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package menu
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/traefik/structor/types"
+)
+
+// Retain applies the retention policy to the branches (ex: "origin/v2.9").
+// It returns the kept branches and the removed branches.
+// The branches which are not semver, the experimental branches, the latest branch, and the branches more recent than the latest version are always kept.
+func Retain(branches []string, config *types.Retention, latest string, experimental []string, debug bool) ([]string, []string, error) {
+	if config == nil || (config.Majors <= 0 && config.Minors <= 0) {
+		return branches, nil, nil
+	}
+
+	var pinned []versionSelector
+	for _, value := range config.Pinned {
+		selector, err := newVersionSelector(value)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid pinned version: %w", err)
+		}
+
+		pinned = append(pinned, selector)
+	}
+
+	latestVersion, err := parseVersion(latest)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid latest version %s: %w", latest, err)
+	}
+
+	var names []string
+	for _, branch := range branches {
+		names = append(names, strings.Replace(branch, baseRemote, "", 1))
+	}
+
+	// the versions more recent than the latest version (ex: release candidates) are not counted.
+	retained := getRetainedMinors(names, latestVersion, config.Majors, config.Minors)
+
+	var kept, removed []string
+	for i, branch := range branches {
+		v, errParse := parseVersion(names[i])
+		if errParse != nil || matchAny(pinned, names[i]) || isRetentionExempt(names[i], v, latestVersion, experimental) {
+			kept = append(kept, branch)
+			continue
+		}
+
+		if _, ok := retained[v.Segments()[0]][v.Segments()[1]]; ok {
+			kept = append(kept, branch)
+			continue
+		}
+
+		if debug {
+			log.Printf("Branch %s removed by the retention policy", branch)
+		}
+
+		removed = append(removed, branch)
+	}
+
+	return kept, removed, nil
+}
+
+// isRetentionExempt checks if a version is always kept: an experimental branch, the latest branch, or a version more recent than the latest version.
+func isRetentionExempt(name string, v, latestVersion *version.Version, experimental []string) bool {
+	for _, exp := range experimental {
+		if name == exp {
+			return true
+		}
+	}
+
+	if v.GreaterThan(latestVersion) {
+		return true
+	}
+
+	return v.Segments()[0] == latestVersion.Segments()[0] && v.Segments()[1] == latestVersion.Segments()[1]
+}
+
+// getRetainedMinors gets the minors kept by the retention policy, by major.
+func getRetainedMinors(names []string, latestVersion *version.Version, majors, minors int) map[int]map[int]struct{} {
+	if minors <= 0 {
+		// all the minors are kept.
+		minors = len(names)
+	}
+
+	retained := getSupportedMinors(names, latestVersion, minors)
+	if majors <= 0 || len(retained) <= majors {
+		return retained
+	}
+
+	var keys []int
+	for major := range retained {
+		keys = append(keys, major)
+	}
+
+	sort.Sort(sort.Reverse(sort.IntSlice(keys)))
+
+	for _, major := range keys[majors:] {
+		delete(retained, major)
+	}
+
+	return retained
+}
+
+// FindNearest finds the nearest kept version of a removed version:
+// the oldest kept version more recent than the removed version, or the most recent kept version.
+func FindNearest(name string, kept []string) string {
+	removed, err := parseVersion(name)
+	if err != nil {
+		return ""
+	}
+
+	var newer, newest *version.Version
+	var newerName, newestName string
+
+	for _, branch := range kept {
+		candidateName := strings.Replace(branch, baseRemote, "", 1)
+
+		candidate, errParse := parseVersion(candidateName)
+		if errParse != nil {
+			continue
+		}
+
+		if candidate.GreaterThan(removed) && (newer == nil || candidate.LessThan(newer)) {
+			newer, newerName = candidate, candidateName
+		}
+
+		if newest == nil || candidate.GreaterThan(newest) {
+			newest, newestName = candidate, candidateName
+		}
+	}
+
+	if newer != nil {
+		return newerName
+	}
+
+	return newestName
+}
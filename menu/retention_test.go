@@ -0,0 +1,104 @@
+package menu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/structor/types"
+)
+
+func TestRetain(t *testing.T) {
+	branches := []string{
+		"origin/master",
+		"origin/v3.1",
+		"origin/v3.0",
+		"origin/v2.11",
+		"origin/v2.10",
+		"origin/v2.9",
+		"origin/v1.7",
+		"origin/v1.6",
+	}
+
+	testCases := []struct {
+		desc            string
+		config          *types.Retention
+		experimental    []string
+		expectedKept    []string
+		expectedRemoved []string
+	}{
+		{
+			desc:         "no policy",
+			expectedKept: branches,
+		},
+		{
+			desc:            "majors",
+			config:          &types.Retention{Majors: 2},
+			expectedKept:    []string{"origin/master", "origin/v3.1", "origin/v3.0", "origin/v2.11", "origin/v2.10", "origin/v2.9"},
+			expectedRemoved: []string{"origin/v1.7", "origin/v1.6"},
+		},
+		{
+			desc:            "minors",
+			config:          &types.Retention{Minors: 1},
+			expectedKept:    []string{"origin/master", "origin/v3.1", "origin/v3.0", "origin/v2.11", "origin/v1.7"},
+			expectedRemoved: []string{"origin/v2.10", "origin/v2.9", "origin/v1.6"},
+		},
+		{
+			desc:            "majors, minors, and pinned versions",
+			config:          &types.Retention{Majors: 2, Minors: 2, Pinned: []string{"v2.9", "v1.*"}},
+			expectedKept:    []string{"origin/master", "origin/v3.1", "origin/v3.0", "origin/v2.11", "origin/v2.10", "origin/v2.9", "origin/v1.7", "origin/v1.6"},
+			expectedRemoved: nil,
+		},
+		{
+			desc:            "majors and minors",
+			config:          &types.Retention{Majors: 2, Minors: 1, Pinned: []string{"v2.9"}},
+			expectedKept:    []string{"origin/master", "origin/v3.1", "origin/v3.0", "origin/v2.11", "origin/v2.9"},
+			expectedRemoved: []string{"origin/v2.10", "origin/v1.7", "origin/v1.6"},
+		},
+		{
+			desc:            "experimental branch",
+			config:          &types.Retention{Majors: 1},
+			experimental:    []string{"master", "v2.9"},
+			expectedKept:    []string{"origin/master", "origin/v3.1", "origin/v3.0", "origin/v2.9"},
+			expectedRemoved: []string{"origin/v2.11", "origin/v2.10", "origin/v1.7", "origin/v1.6"},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			// v3.1 is a release candidate, more recent than the latest version.
+			kept, removed, err := Retain(branches, test.config, "v3.0.2", test.experimental, true)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expectedKept, kept)
+			assert.Equal(t, test.expectedRemoved, removed)
+		})
+	}
+}
+
+func TestFindNearest(t *testing.T) {
+	kept := []string{"origin/master", "origin/v3.1", "origin/v2.11", "origin/v2.9"}
+
+	testCases := []struct {
+		name     string
+		expected string
+	}{
+		{name: "v2.10", expected: "v2.11"},
+		{name: "v1.7", expected: "v2.9"},
+		{name: "v3.0", expected: "v3.1"},
+		{name: "v4.0", expected: "v3.1"},
+		{name: "v2.x-legacy", expected: ""},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, FindNearest(test.name, kept))
+		})
+	}
+}
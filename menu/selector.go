@@ -0,0 +1,94 @@
+package menu
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+)
+
+// versionSelector selects versions by name, by glob (ex: "v2.0*"), by regular expression (ex: "/^v2\.\d+$/"),
+// or by semver constraint (ex: "v2.11", ">= 2.0, < 2.5").
+type versionSelector struct {
+	name       string
+	constraint version.Constraints
+	glob       string
+	pattern    *regexp.Regexp
+}
+
+func newVersionSelector(value string) (versionSelector, error) {
+	switch {
+	case len(value) > 2 && strings.HasPrefix(value, "/") && strings.HasSuffix(value, "/"):
+		pattern, err := regexp.Compile(value[1 : len(value)-1])
+		if err != nil {
+			return versionSelector{}, fmt.Errorf("invalid versions %q: %w", value, err)
+		}
+
+		return versionSelector{name: value, pattern: pattern}, nil
+
+	case strings.ContainsAny(value, "*?["):
+		if _, err := path.Match(value, ""); err != nil {
+			return versionSelector{}, fmt.Errorf("invalid versions %q: %w", value, err)
+		}
+
+		return versionSelector{name: value, glob: value}, nil
+	}
+
+	if _, err := version.NewVersion(value); err == nil {
+		return versionSelector{name: value}, nil
+	}
+
+	constraint, err := version.NewConstraint(value)
+	if err != nil {
+		// not a constraint: a non-semver version name.
+		if value != "" && !hasConstraintOperator(value) {
+			return versionSelector{name: value}, nil
+		}
+
+		return versionSelector{}, fmt.Errorf("invalid versions %q: %w", value, err)
+	}
+
+	return versionSelector{name: value, constraint: constraint}, nil
+}
+
+// match checks if a version matches the selector.
+// The version (v) is nil when the version name is not semver.
+func (s versionSelector) match(versionName string, v *version.Version) bool {
+	if versionName == s.name {
+		return true
+	}
+
+	if s.pattern != nil {
+		return s.pattern.MatchString(versionName)
+	}
+
+	if s.glob != "" {
+		matched, _ := path.Match(s.glob, versionName)
+		return matched
+	}
+
+	return s.constraint != nil && v != nil && s.constraint.Check(v)
+}
+
+// matchName checks if a version name matches the selector.
+func (s versionSelector) matchName(versionName string) bool {
+	v, err := parseVersion(versionName)
+	if err != nil {
+		return s.match(versionName, nil)
+	}
+
+	return s.match(versionName, v)
+}
+
+func hasConstraintOperator(value string) bool {
+	for _, c := range value {
+		switch c {
+		case '<', '>', '=', '~', '!', ',', ' ':
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,145 @@
+package menu
+
+import (
+	"fmt"
+	"html"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/traefik/structor/manifest"
+)
+
+const (
+	// StaticNav the versions are added to the navigation ("nav") of the manifest.
+	StaticNav = "nav"
+	// StaticPartial the versions are written into a theme partial ("custom_dir").
+	StaticPartial = "partial"
+)
+
+const (
+	staticNavSection      = "Versions"
+	staticPartialFileName = "structor-versions.html"
+	overridesDirName      = "structor-overrides"
+)
+
+// IsValidStatic checks if a static selector mode is valid.
+func IsValidStatic(name string) bool {
+	return name == "" || name == StaticNav || name == StaticPartial
+}
+
+// buildStatic adds the static (JavaScript-free) version selector to the manifest, or to a theme partial.
+func buildStatic(manif, effective map[string]interface{}, manifestFile, mode string, model templateModel) error {
+	switch mode {
+	case StaticNav:
+		addStaticNav(manif, effective, model)
+		return nil
+	case StaticPartial:
+		return writeStaticPartial(manif, effective, manifestFile, model)
+	default:
+		return nil
+	}
+}
+
+// addStaticNav adds a "Versions" section, with a link for each version, to the navigation of the manifest.
+// The navigation is not created when it's not defined, because it would replace the navigation generated by MkDocs.
+func addStaticNav(manif, effective map[string]interface{}, model templateModel) {
+	// the lists of a child manifest replace the lists of its parent.
+	manifest.ResolveInherited(manif, effective, "nav")
+
+	nav, ok := manif["nav"].([]interface{})
+	if !ok {
+		log.Printf("[WARN] no nav defined in the manifest of %s: the versions are not added to the nav.", model.Current)
+		return
+	}
+
+	var items []interface{}
+	for _, v := range model.Versions {
+		items = append(items, map[string]interface{}{
+			translateLabel(v, model.Locale): getStaticURL(model.BasePath, v),
+		})
+	}
+
+	manif["nav"] = append(nav, map[string]interface{}{staticNavSection: items})
+}
+
+// writeStaticPartial writes the theme partial of the versions into the custom directory of the theme.
+// The partial is included by the theme overrides: {% include "structor-versions.html" %}.
+func writeStaticPartial(manif, effective map[string]interface{}, manifestFile string, model templateModel) error {
+	customDir := getCustomDir(manif, effective, manifestFile)
+
+	err := os.MkdirAll(customDir, os.ModePerm)
+	if err != nil {
+		return fmt.Errorf("failed to create the theme custom directory %s: %w", customDir, err)
+	}
+
+	err = os.WriteFile(filepath.Join(customDir, staticPartialFileName), []byte(buildStaticPartial(model)), os.ModePerm)
+	if err != nil {
+		return fmt.Errorf("failed to write the versions partial: %w", err)
+	}
+
+	return nil
+}
+
+func buildStaticPartial(model templateModel) string {
+	b := &strings.Builder{}
+	b.WriteString(`<nav class="structor-versions" aria-label="Versions">` + "\n<ul>\n")
+
+	for _, v := range model.Versions {
+		href := html.EscapeString(getStaticURL(model.BasePath, v))
+
+		class := "structor-version"
+		if v.Selected {
+			class += " structor-version-selected"
+		}
+
+		fmt.Fprintf(b, `<li class="%s"><a href="%s">%s</a></li>`+"\n", class, href, html.EscapeString(translateLabel(v, model.Locale)))
+	}
+
+	b.WriteString("</ul>\n</nav>\n")
+
+	return b.String()
+}
+
+// getStaticURL gets the URL of a version: an absolute path from the base path of the site, when the URL of the version is not defined.
+// The URLs can't be relative: the latest version is also served at its permalink (ex: "/v2.1/").
+func getStaticURL(basePath string, v optionVersion) string {
+	if v.URL != "" {
+		return v.URL
+	}
+
+	if v.Path == "" {
+		return basePath
+	}
+
+	return basePath + v.Path + "/"
+}
+
+// getCustomDir gets the custom directory of the theme, and defines it in the manifest when it's not defined.
+func getCustomDir(manif, effective map[string]interface{}, manifestFile string) string {
+	manifestDir := filepath.Dir(manifestFile)
+
+	if theme, ok := effective["theme"].(map[string]interface{}); ok {
+		if customDir, ok := theme["custom_dir"].(string); ok && customDir != "" {
+			return filepath.Join(manifestDir, customDir)
+		}
+	}
+
+	manifest.ResolveInherited(manif, effective, "theme")
+
+	var theme map[string]interface{}
+	switch value := manif["theme"].(type) {
+	case map[string]interface{}:
+		theme = value
+	case string:
+		theme = map[string]interface{}{"name": value}
+	default:
+		theme = map[string]interface{}{}
+	}
+
+	theme["custom_dir"] = overridesDirName
+	manif["theme"] = theme
+
+	return filepath.Join(manifestDir, overridesDirName)
+}
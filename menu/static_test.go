@@ -0,0 +1,175 @@
+package menu
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_getStaticURL(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		basePath string
+		version  optionVersion
+		expected string
+	}{
+		{
+			desc:     "latest version",
+			basePath: "/",
+			version:  optionVersion{Name: "v2.1"},
+			expected: "/",
+		},
+		{
+			desc:     "version",
+			basePath: "/",
+			version:  optionVersion{Name: "v2.0", Path: "v2.0"},
+			expected: "/v2.0/",
+		},
+		{
+			desc:     "base path",
+			basePath: "/traefik/",
+			version:  optionVersion{Name: "feature/foo", Path: "preview/foo"},
+			expected: "/traefik/preview/foo/",
+		},
+		{
+			desc:     "URL",
+			basePath: "/traefik/",
+			version:  optionVersion{Name: "v1.7", URL: "https://v1.doc.traefik.io/traefik/"},
+			expected: "https://v1.doc.traefik.io/traefik/",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, getStaticURL(test.basePath, test.version))
+		})
+	}
+}
+
+func Test_addStaticNav(t *testing.T) {
+	// the latest version is also served at its permalink ("/traefik/v2.1/"): the URLs are absolute paths.
+	model := templateModel{
+		Current:  "v2.1",
+		BasePath: "/traefik/",
+		Versions: []optionVersion{
+			{Name: "v2.1", Text: "v2.1 Latest", Selected: true},
+			{Name: "v2.0", Text: "v2.0", Path: "v2.0"},
+		},
+	}
+
+	testCases := []struct {
+		desc      string
+		manif     map[string]interface{}
+		effective map[string]interface{}
+		expected  map[string]interface{}
+	}{
+		{
+			desc:      "nav",
+			manif:     map[string]interface{}{"nav": []interface{}{"index.md"}},
+			effective: map[string]interface{}{"nav": []interface{}{"index.md"}},
+			expected: map[string]interface{}{"nav": []interface{}{
+				"index.md",
+				map[string]interface{}{"Versions": []interface{}{
+					map[string]interface{}{"v2.1 Latest": "/traefik/"},
+					map[string]interface{}{"v2.0": "/traefik/v2.0/"},
+				}},
+			}},
+		},
+		{
+			desc:      "inherited nav",
+			manif:     map[string]interface{}{},
+			effective: map[string]interface{}{"nav": []interface{}{"index.md"}},
+			expected: map[string]interface{}{"nav": []interface{}{
+				"index.md",
+				map[string]interface{}{"Versions": []interface{}{
+					map[string]interface{}{"v2.1 Latest": "/traefik/"},
+					map[string]interface{}{"v2.0": "/traefik/v2.0/"},
+				}},
+			}},
+		},
+		{
+			desc:      "without nav",
+			manif:     map[string]interface{}{},
+			effective: map[string]interface{}{},
+			expected:  map[string]interface{}{},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			addStaticNav(test.manif, test.effective, model)
+
+			assert.Equal(t, test.expected, test.manif)
+		})
+	}
+}
+
+func Test_writeStaticPartial(t *testing.T) {
+	model := templateModel{
+		Current:  "v2.0",
+		BasePath: "/traefik/",
+		Versions: []optionVersion{
+			{Name: "v2.1", Text: "v2.1 Latest"},
+			{Name: "v2.0", Text: "v2.0", Path: "v2.0", Selected: true},
+			{Name: "v1.7", Text: "v1.7", URL: "https://v1.doc.traefik.io/traefik/"},
+		},
+	}
+
+	testCases := []struct {
+		desc          string
+		manif         map[string]interface{}
+		expectedDir   string
+		expectedManif map[string]interface{}
+	}{
+		{
+			desc:          "theme name",
+			manif:         map[string]interface{}{"theme": "material"},
+			expectedDir:   "structor-overrides",
+			expectedManif: map[string]interface{}{"theme": map[string]interface{}{"name": "material", "custom_dir": "structor-overrides"}},
+		},
+		{
+			desc:          "existing custom directory",
+			manif:         map[string]interface{}{"theme": map[string]interface{}{"name": "material", "custom_dir": "overrides"}},
+			expectedDir:   "overrides",
+			expectedManif: map[string]interface{}{"theme": map[string]interface{}{"name": "material", "custom_dir": "overrides"}},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			dir, err := os.MkdirTemp("", "structor-test")
+			require.NoError(t, err)
+			defer func() { _ = os.RemoveAll(dir) }()
+
+			err = writeStaticPartial(test.manif, test.manif, filepath.Join(dir, "mkdocs.yml"), model)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expectedManif, test.manif)
+
+			content, err := os.ReadFile(filepath.Join(dir, test.expectedDir, staticPartialFileName))
+			require.NoError(t, err)
+
+			expected := `<nav class="structor-versions" aria-label="Versions">
+<ul>
+<li class="structor-version"><a href="/traefik/">v2.1 Latest</a></li>
+<li class="structor-version structor-version-selected"><a href="/traefik/v2.0/">v2.0</a></li>
+<li class="structor-version"><a href="https://v1.doc.traefik.io/traefik/">v1.7</a></li>
+</ul>
+</nav>
+`
+			assert.Equal(t, expected, string(content))
+		})
+	}
+}
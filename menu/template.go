@@ -0,0 +1,163 @@
+package menu
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"text/template"
+	"time"
+
+	"github.com/Masterminds/sprig/v3"
+	"github.com/traefik/structor/types"
+)
+
+// templateModel the model of the menu templates (JS, CSS, and assets).
+// The fields are a stable contract for the template authors, documented by the "description" tags.
+type templateModel struct {
+	Owner        string          `description:"The repository owner."`
+	Repository   string          `description:"The repository name."`
+	SiteURL      string          `description:"The site URL (--site-url)."`
+	BasePath     string          `description:"The base path (--base-path), or the path of the site URL, with a leading and a trailing slash (ex: '/traefik/'). '/' when not defined."`
+	Latest       string          `description:"The latest release tag name."`
+	Experimental string          `description:"The experimental branch name."`
+	Current      string          `description:"The current version name."`
+	Commit       string          `description:"The source commit SHA of the current version."`
+	Date         time.Time       `description:"The source commit date of the current version."`
+	Locale       string          `description:"The locale of the theme ('theme.locale' or 'theme.language') defined in the manifest of the current version."`
+	Versions     []optionVersion `description:"The versions of the menu, from the newest to the oldest."`
+	Banner       string          `description:"The HTML banner of the current version (banner settings), empty if none. The URL of the same page in the latest version is the placeholder '__STRUCTOR_LATEST_URL__'."`
+}
+
+type templateFunc struct {
+	Name        string
+	Usage       string
+	Description string
+	Fn          interface{}
+}
+
+func newTemplateModel(versionsInfo types.VersionsInformation, versions []optionVersion, siteURL, locale string) templateModel {
+	commit := versionsInfo.Commits[versionsInfo.Current]
+
+	return templateModel{
+		Owner:        versionsInfo.Owner,
+		Repository:   versionsInfo.Repository,
+		SiteURL:      siteURL,
+		BasePath:     GetBasePath(siteURL, versionsInfo.BasePath),
+		Latest:       versionsInfo.Latest,
+		Experimental: versionsInfo.Experimental,
+		Current:      versionsInfo.Current,
+		Commit:       commit.SHA,
+		Date:         commit.Date,
+		Locale:       locale,
+		Versions:     versions,
+	}
+}
+
+// setBanner sets the banner of the current version, the URL of the latest version is replaced at runtime.
+func (m *templateModel) setBanner(config *types.Banner) error {
+	banner, err := buildBanner(config, getSelectedVersion(m.Versions), bannerLatestURL)
+	if err != nil {
+		return err
+	}
+
+	m.Banner = banner
+
+	return nil
+}
+
+// GetBasePath gets the base path of the site (ex: "/traefik/"), from the base path or from the path of the site URL.
+func GetBasePath(siteURL, basePath string) string {
+	if basePath != "" {
+		return normalizeBasePath(basePath)
+	}
+
+	u, err := url.Parse(siteURL)
+	if err != nil || u.Path == "" {
+		return "/"
+	}
+
+	return normalizeBasePath(u.Path)
+}
+
+func customTemplateFuncs() []templateFunc {
+	return []templateFunc{
+		{
+			Name:        "IsObsolete",
+			Usage:       "IsObsolete .Versions .Current",
+			Description: "Returns true if the state of the named version is OBSOLETE.",
+			Fn: func(versions []optionVersion, current string) bool {
+				for _, v := range versions {
+					if v.Name == current && v.State == stateObsolete {
+						return true
+					}
+				}
+				return false
+			},
+		},
+		{
+			Name:        "Label",
+			Usage:       "Label $version .Locale",
+			Description: "Returns the display label of a version translated for a locale (ex: 'zh-CN', then 'zh'), or its default label.",
+			Fn:          translateLabel,
+		},
+	}
+}
+
+func templateFuncMap() template.FuncMap {
+	funcMap := sprig.TxtFuncMap()
+
+	for _, fn := range customTemplateFuncs() {
+		funcMap[fn.Name] = fn.Fn
+	}
+
+	return funcMap
+}
+
+// writeTemplateFile renders a template into a directory of the theme, inside the docs directory.
+// Returns the path of the file relative to the docs directory.
+func writeTemplateFile(manifestDocsDir, themeDir, fileName, content string, model templateModel) (string, error) {
+	dir := filepath.Join(manifestDocsDir, "theme", themeDir)
+	if _, errStat := os.Stat(dir); os.IsNotExist(errStat) {
+		errDir := os.MkdirAll(dir, os.ModePerm)
+		if errDir != nil {
+			return "", fmt.Errorf("error when create %s folder: %w", themeDir, errDir)
+		}
+	}
+
+	err := buildFile(filepath.Join(dir, fileName), fileName, content, model)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join("theme", themeDir, fileName), nil
+}
+
+func buildFile(filePath, name, content string, model templateModel) error {
+	f, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("error when create menu file: %w", err)
+	}
+	defer safeClose(f.Close)
+
+	return renderTemplate(f, name, content, model)
+}
+
+func renderTemplate(w io.Writer, name, content string, model templateModel) error {
+	temp := template.New(name).Funcs(templateFuncMap())
+
+	_, err := temp.Parse(content)
+	if err != nil {
+		return fmt.Errorf("error during parsing template: %w", err)
+	}
+
+	return temp.Execute(w, model)
+}
+
+func safeClose(fn func() error) {
+	if err := fn(); err != nil {
+		log.Println(err)
+	}
+}
@@ -0,0 +1,94 @@
+package menu
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/traefik/structor/types"
+)
+
+func Test_getBasePath(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		siteURL  string
+		basePath string
+		expected string
+	}{
+		{
+			desc:     "empty",
+			siteURL:  "",
+			expected: "/",
+		},
+		{
+			desc:     "without path",
+			siteURL:  "https://docs.traefik.io",
+			expected: "/",
+		},
+		{
+			desc:     "with path",
+			siteURL:  "https://doc.traefik.io/traefik",
+			expected: "/traefik/",
+		},
+		{
+			desc:     "with path and trailing slash",
+			siteURL:  "https://doc.traefik.io/traefik/",
+			expected: "/traefik/",
+		},
+		{
+			desc:     "base path",
+			siteURL:  "https://doc.traefik.io/traefik/",
+			basePath: "mesh",
+			expected: "/mesh/",
+		},
+		{
+			desc:     "root base path",
+			basePath: "/",
+			expected: "/",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, GetBasePath(test.siteURL, test.basePath))
+		})
+	}
+}
+
+func Test_newTemplateModel(t *testing.T) {
+	date := time.Date(2023, time.February, 14, 10, 11, 12, 0, time.UTC)
+
+	versionsInfo := types.VersionsInformation{
+		Owner:        "traefik",
+		Repository:   "structor",
+		Current:      "v1.4",
+		Latest:       "v1.4.6",
+		Experimental: "master",
+		Commits: map[string]types.CommitInformation{
+			"v1.4": {SHA: "aaa", Date: date},
+		},
+	}
+
+	versions := []optionVersion{{Name: "v1.4"}}
+
+	model := newTemplateModel(versionsInfo, versions, "https://doc.traefik.io/structor/", "zh")
+
+	expected := templateModel{
+		Owner:        "traefik",
+		Repository:   "structor",
+		SiteURL:      "https://doc.traefik.io/structor/",
+		BasePath:     "/structor/",
+		Latest:       "v1.4.6",
+		Experimental: "master",
+		Current:      "v1.4",
+		Commit:       "aaa",
+		Date:         date,
+		Locale:       "zh",
+		Versions:     versions,
+	}
+
+	assert.Equal(t, expected, model)
+}
@@ -0,0 +1,89 @@
+package menu
+
+import (
+	"embed"
+	"fmt"
+	"log"
+	"path"
+)
+
+// ThemeAuto selects the built-in menu templates from the theme defined in the manifest of each version.
+const ThemeAuto = "auto"
+
+const (
+	themeMaterial    = "material"
+	themeReadTheDocs = "readthedocs"
+	themeMkDocs      = "mkdocs"
+	themeBootstrap   = "bootstrap"
+)
+
+//go:embed themes
+var themesFS embed.FS
+
+// IsValidTheme checks if a theme name can be used to select the built-in menu templates.
+func IsValidTheme(name string) bool {
+	switch name {
+	case ThemeAuto, themeMaterial, themeReadTheDocs, themeMkDocs, themeBootstrap:
+		return true
+	default:
+		return false
+	}
+}
+
+// withTheme fills the missing menu templates with the built-in templates of a theme.
+func (c Content) withTheme(name string) (Content, error) {
+	if name == "" {
+		return c, nil
+	}
+
+	if len(c.Js) == 0 {
+		common, err := themesFS.ReadFile(path.Join("themes", "common.js.gotmpl"))
+		if err != nil {
+			return Content{}, fmt.Errorf("failed to read built-in menu template: %w", err)
+		}
+
+		js, err := themesFS.ReadFile(path.Join("themes", name+".js.gotmpl"))
+		if err != nil {
+			return Content{}, fmt.Errorf("failed to read built-in menu template for theme %s: %w", name, err)
+		}
+
+		c.Js = append(common, js...)
+	}
+
+	if len(c.CSS) == 0 {
+		css, err := themesFS.ReadFile(path.Join("themes", name+".css.gotmpl"))
+		if err != nil {
+			return Content{}, fmt.Errorf("failed to read built-in menu template for theme %s: %w", name, err)
+		}
+
+		c.CSS = css
+	}
+
+	return c, nil
+}
+
+// detectTheme returns the name of the built-in menu templates matching the theme of the manifest.
+func detectTheme(manif map[string]interface{}) string {
+	var name string
+
+	switch theme := manif["theme"].(type) {
+	case string:
+		name = theme
+	case map[string]interface{}:
+		name, _ = theme["name"].(string)
+	}
+
+	switch name {
+	case themeMaterial, themeReadTheDocs, themeMkDocs:
+		return name
+
+	// the MkDocs themes based on Bootstrap (https://mkdocs.github.io/mkdocs-bootswatch/).
+	case "bootstrap", "bootstrap4", "cerulean", "cosmo", "cyborg", "darkly", "flatly", "journal", "litera", "lumen", "lux",
+		"materia", "minty", "pulse", "sandstone", "simplex", "slate", "solar", "spacelab", "superhero", "united", "yeti":
+		return themeBootstrap
+	}
+
+	log.Printf("[WARN] no built-in menu templates for the theme %q.", name)
+
+	return ""
+}
@@ -0,0 +1,172 @@
+package menu
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/structor/types"
+)
+
+func Test_detectTheme(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		manif    map[string]interface{}
+		expected string
+	}{
+		{
+			desc:     "no theme",
+			manif:    map[string]interface{}{},
+			expected: "",
+		},
+		{
+			desc:     "theme as string",
+			manif:    map[string]interface{}{"theme": "readthedocs"},
+			expected: themeReadTheDocs,
+		},
+		{
+			desc: "theme as map",
+			manif: map[string]interface{}{
+				"theme": map[string]interface{}{"name": "material"},
+			},
+			expected: themeMaterial,
+		},
+		{
+			desc:     "mkdocs theme",
+			manif:    map[string]interface{}{"theme": "mkdocs"},
+			expected: themeMkDocs,
+		},
+		{
+			desc: "bootswatch theme",
+			manif: map[string]interface{}{
+				"theme": map[string]interface{}{"name": "united"},
+			},
+			expected: themeBootstrap,
+		},
+		{
+			desc:     "unknown theme",
+			manif:    map[string]interface{}{"theme": "foo"},
+			expected: "",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, detectTheme(test.manif))
+		})
+	}
+}
+
+func TestContent_withTheme(t *testing.T) {
+	content, err := Content{CSS: []byte("foo")}.withTheme(themeMaterial)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(content.Js), "var structorVersions")
+	assert.Contains(t, string(content.Js), "// Material theme")
+	assert.Equal(t, "foo", string(content.CSS))
+
+	_, err = Content{}.withTheme("foo")
+	require.Error(t, err)
+}
+
+func Test_buildJSFile_themes(t *testing.T) {
+	versionsInfo := types.VersionsInformation{
+		Current:      "v1.10",
+		Latest:       "v1.9.6",
+		Experimental: "master",
+	}
+
+	versions, err := buildVersions(versionsInfo, []string{"origin/v1.9", "origin/master", "origin/v1.10"})
+	require.NoError(t, err)
+
+	for _, theme := range []string{themeMaterial, themeReadTheDocs, themeMkDocs, themeBootstrap} {
+		theme := theme
+		t.Run(theme, func(t *testing.T) {
+			content, err := Content{}.withTheme(theme)
+			require.NoError(t, err)
+
+			dir, err := os.MkdirTemp("", "structor-test")
+			require.NoError(t, err)
+			defer func() { _ = os.RemoveAll(dir) }()
+
+			jsFile := filepath.Join(dir, "menu.js")
+
+			err = buildFile(jsFile, "menu-js", string(content.Js), newTemplateModel(versionsInfo, versions, "", ""))
+			require.NoError(t, err)
+
+			js, err := os.ReadFile(jsFile)
+			require.NoError(t, err)
+
+			assert.Contains(t, string(js), `{name: "v1.10", path: "v1.10", text: "v1.10 RC", state: "PRE_FINAL_RELEASE", url: "", selected: true},`)
+		})
+	}
+}
+
+func Test_structorLatestURL(t *testing.T) {
+	node, err := exec.LookPath("node")
+	if err != nil {
+		t.Skip("node is required")
+	}
+
+	versionsInfo := types.VersionsInformation{
+		Current:      "v1.8",
+		Latest:       "v1.9.6",
+		Experimental: "master",
+	}
+
+	versions, err := buildVersions(versionsInfo, []string{"origin/v1.9", "origin/v1.8", "origin/master"})
+	require.NoError(t, err)
+
+	content, err := Content{}.withTheme(themeMkDocs)
+	require.NoError(t, err)
+
+	dir, err := os.MkdirTemp("", "structor-test")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	jsFile := filepath.Join(dir, "menu.js")
+
+	err = buildFile(jsFile, "menu-js", string(content.Js), newTemplateModel(versionsInfo, versions, "", ""))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc      string
+		scriptURL string
+		pageURL   string
+		expected  string
+	}{
+		{
+			desc:      "server",
+			scriptURL: "https://example.com/docs/v1.8/theme/js/structor-menu.js",
+			pageURL:   "https://example.com/docs/v1.8/routing/overview/",
+			expected:  "https://example.com/docs/routing/overview/",
+		},
+		{
+			desc:      "file system",
+			scriptURL: "file:///tmp/site/v1.8/theme/js/structor-menu.js",
+			pageURL:   "file:///tmp/site/v1.8/routing/overview/index.html",
+			expected:  "file:///tmp/site/routing/overview/index.html",
+		},
+		{
+			desc:      "file system, index of the version",
+			scriptURL: "file:///tmp/site/v1.8/theme/js/structor-menu.js",
+			pageURL:   "file:///tmp/site/v1.8/index.html",
+			expected:  "file:///tmp/site/index.html",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			output, err := exec.Command(node, filepath.Join(".", "fixtures", "latest-url.js"), jsFile, test.scriptURL, test.pageURL).CombinedOutput()
+			require.NoError(t, err, string(output))
+
+			assert.Equal(t, test.expected, string(output))
+		})
+	}
+}
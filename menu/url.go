@@ -0,0 +1,45 @@
+package menu
+
+import "strings"
+
+// setURLs sets the absolute URLs of the versions served by the site, when the site URL or the base path is defined.
+// Otherwise, the URLs stay empty: the menu computes them from the root of the site (relative mode).
+func setURLs(versions []optionVersion, siteURL, basePath string) {
+	base := getURLBase(siteURL, basePath)
+	if base == "" {
+		return
+	}
+
+	for i, v := range versions {
+		if v.URL != "" {
+			// external version.
+			continue
+		}
+
+		versions[i].URL = base
+		if v.Path != "" {
+			versions[i].URL += v.Path + "/"
+		}
+	}
+}
+
+func getURLBase(siteURL, basePath string) string {
+	switch {
+	case siteURL != "":
+		return strings.TrimSuffix(siteURL, "/") + "/"
+	case basePath != "":
+		return normalizeBasePath(basePath)
+	default:
+		return ""
+	}
+}
+
+// normalizeBasePath adds a leading and a trailing slash to a base path (ex: "traefik" -> "/traefik/").
+func normalizeBasePath(basePath string) string {
+	p := strings.Trim(basePath, "/")
+	if p == "" {
+		return "/"
+	}
+
+	return "/" + p + "/"
+}
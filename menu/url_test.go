@@ -0,0 +1,64 @@
+package menu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_setURLs(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		siteURL  string
+		basePath string
+		expected []string
+	}{
+		{
+			desc:     "relative",
+			expected: []string{"", "", "https://v1.doc.traefik.io/traefik/"},
+		},
+		{
+			desc:     "site URL",
+			siteURL:  "https://doc.traefik.io/traefik",
+			expected: []string{"https://doc.traefik.io/traefik/master/", "https://doc.traefik.io/traefik/", "https://v1.doc.traefik.io/traefik/"},
+		},
+		{
+			desc:     "base path",
+			basePath: "traefik",
+			expected: []string{"/traefik/master/", "/traefik/", "https://v1.doc.traefik.io/traefik/"},
+		},
+		{
+			desc:     "root base path",
+			basePath: "/",
+			expected: []string{"/master/", "/", "https://v1.doc.traefik.io/traefik/"},
+		},
+		{
+			desc:     "site URL and base path",
+			siteURL:  "https://doc.traefik.io/traefik/",
+			basePath: "/foo/",
+			expected: []string{"https://doc.traefik.io/traefik/master/", "https://doc.traefik.io/traefik/", "https://v1.doc.traefik.io/traefik/"},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			versions := []optionVersion{
+				{Name: "master", Path: "master"},
+				{Name: "v2.1"},
+				{Name: "v1.7", URL: "https://v1.doc.traefik.io/traefik/"},
+			}
+
+			setURLs(versions, test.siteURL, test.basePath)
+
+			var urls []string
+			for _, v := range versions {
+				urls = append(urls, v.URL)
+			}
+
+			assert.Equal(t, test.expected, urls)
+		})
+	}
+}
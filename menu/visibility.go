@@ -0,0 +1,100 @@
+package menu
+
+import (
+	"fmt"
+
+	"github.com/traefik/structor/types"
+)
+
+type visibilityRule struct {
+	selector versionSelector
+	hidden   bool
+	listedOn []versionSelector
+	external string
+}
+
+// applyVisibility removes the versions which must not be listed in the menu of the current version,
+// and sets the URL of the external versions.
+func applyVisibility(versions []optionVersion, config []types.VisibilityRule, current string) ([]optionVersion, error) {
+	if len(config) == 0 {
+		return versions, nil
+	}
+
+	rules, err := newVisibilityRules(config)
+	if err != nil {
+		return nil, err
+	}
+
+	var listed []optionVersion
+	for _, v := range versions {
+		rule, ok := findVisibilityRule(rules, v.Name)
+		if !ok {
+			listed = append(listed, v)
+			continue
+		}
+
+		if rule.external != "" {
+			v.URL = rule.external
+		}
+
+		if v.Selected || rule.isListedOn(current) {
+			listed = append(listed, v)
+		}
+	}
+
+	return listed, nil
+}
+
+func newVisibilityRules(config []types.VisibilityRule) ([]visibilityRule, error) {
+	var rules []visibilityRule
+	for _, c := range config {
+		selector, err := newVersionSelector(c.Versions)
+		if err != nil {
+			return nil, fmt.Errorf("invalid visibility rule: %w", err)
+		}
+
+		rule := visibilityRule{selector: selector, hidden: c.Hidden, external: c.External}
+
+		for _, value := range c.ListedOn {
+			listedOn, err := newVersionSelector(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid visibility rule %q: %w", c.Versions, err)
+			}
+
+			rule.listedOn = append(rule.listedOn, listedOn)
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+func findVisibilityRule(rules []visibilityRule, versionName string) (visibilityRule, bool) {
+	for _, rule := range rules {
+		if rule.selector.matchName(versionName) {
+			return rule, true
+		}
+	}
+
+	return visibilityRule{}, false
+}
+
+// isListedOn checks if the versions of the rule are listed in the menu of the current version.
+func (r visibilityRule) isListedOn(current string) bool {
+	if r.hidden {
+		return false
+	}
+
+	if len(r.listedOn) == 0 {
+		return true
+	}
+
+	for _, selector := range r.listedOn {
+		if selector.matchName(current) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,81 @@
+package menu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/structor/types"
+)
+
+func Test_applyVisibility(t *testing.T) {
+	config := []types.VisibilityRule{
+		{Versions: "v4.0", Hidden: true},
+		{Versions: "partner-acme", ListedOn: []string{"partner-acme", ">= 3.0"}},
+		{Versions: "< 2.0", External: "https://v1.doc.traefik.io/traefik/"},
+	}
+
+	versions := []optionVersion{
+		{Name: "v4.0", Path: "v4.0"},
+		{Name: "v3.0", Path: ""},
+		{Name: "v2.0", Path: "v2.0"},
+		{Name: "v1.7", Path: "v1.7"},
+		{Name: "partner-acme", Path: "partner-acme"},
+	}
+
+	testCases := []struct {
+		desc     string
+		current  string
+		expected []string
+	}{
+		{
+			desc:     "hidden and listed on the current version",
+			current:  "v3.0",
+			expected: []string{"v3.0", "v2.0", "v1.7", "partner-acme"},
+		},
+		{
+			desc:     "not listed on the current version",
+			current:  "v2.0",
+			expected: []string{"v3.0", "v2.0", "v1.7"},
+		},
+		{
+			desc:     "hidden version listed in its own menu",
+			current:  "v4.0",
+			expected: []string{"v4.0", "v3.0", "v2.0", "v1.7", "partner-acme"},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var vs []optionVersion
+			for _, v := range versions {
+				v.Selected = v.Name == test.current
+				vs = append(vs, v)
+			}
+
+			listed, err := applyVisibility(vs, config, test.current)
+			require.NoError(t, err)
+
+			var names []string
+			for _, v := range listed {
+				names = append(names, v.Name)
+
+				if v.Name == "v1.7" {
+					assert.Equal(t, "https://v1.doc.traefik.io/traefik/", v.URL)
+				} else {
+					assert.Empty(t, v.URL)
+				}
+			}
+
+			assert.Equal(t, test.expected, names)
+		})
+	}
+}
+
+func Test_applyVisibility_error(t *testing.T) {
+	_, err := applyVisibility([]optionVersion{{Name: "v1.0"}}, []types.VisibilityRule{{Versions: ">= foo", Hidden: true}}, "v1.0")
+	assert.Error(t, err)
+}
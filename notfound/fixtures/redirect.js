@@ -0,0 +1,26 @@
+// Evaluates the script of the 404 page in a fake browser, and prints the redirection target.
+// Usage: node redirect.js <404 page> <pages.json file> <path of the page>
+var fs = require('fs');
+
+var page = fs.readFileSync(process.argv[2], 'utf8');
+var script = page.substring(page.indexOf('<script>') + '<script>'.length, page.indexOf('</script>'));
+var inventory = JSON.parse(fs.readFileSync(process.argv[3], 'utf8'));
+
+global.window = {
+  fetch: true,
+  location: {
+    pathname: process.argv[4],
+    replace: function (target) {
+      process.stdout.write(target);
+    },
+  },
+};
+global.fetch = function () {
+  return Promise.resolve({
+    json: function () {
+      return inventory;
+    },
+  });
+};
+
+(0, eval)(script);
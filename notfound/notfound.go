@@ -0,0 +1,207 @@
+package notfound
+
+import (
+	"bytes"
+	_ "embed" // the template of the 404 page.
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/traefik/structor/menu"
+	"github.com/traefik/structor/types"
+)
+
+// PagesFileName the name of the inventory of the pages of the versions, at the root of the site.
+const PagesFileName = "pages.json"
+
+const (
+	fileName       = "404.html"
+	defaultTitle   = "Page not found"
+	defaultMessage = "This page doesn't exist. The available versions of the documentation are:"
+)
+
+//go:embed 404.html.gotmpl
+var pageTemplate string
+
+// pages the inventory of the pages of the versions.
+type pages struct {
+	Latest   versionPages   `json:"latest"`
+	Versions []versionPages `json:"versions"`
+}
+
+// versionPages the pages of a version, relative to the root of the version (ex: "routing/overview/").
+type versionPages struct {
+	Name  string   `json:"name"`
+	Path  string   `json:"path"`
+	Pages []string `json:"pages"`
+}
+
+type pageModel struct {
+	Title         string
+	Message       string
+	BasePath      string
+	PagesFileName string
+	Versions      []versionLink
+}
+
+type versionLink struct {
+	Text string
+	URL  string
+}
+
+// Build writes the inventory of the pages of the versions, and the 404 page at the root of the site (and of each version).
+// The 404 page redirects to the same page in the nearest more recent version, or in the latest version, else it lists the versions.
+// The hidden versions are neither listed nor targets of the redirections.
+func Build(siteDir, basePath string, inventory menu.Inventory, config *types.NotFound) error {
+	inventoryPages := buildPages(siteDir, inventory)
+
+	content, err := json.Marshal(inventoryPages)
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(filepath.Join(siteDir, PagesFileName), content, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", PagesFileName, err)
+	}
+
+	page, err := buildPage(basePath, inventory, config)
+	if err != nil {
+		return err
+	}
+
+	dirs := []string{siteDir}
+	if config.PerVersion {
+		for _, v := range inventoryPages.Versions {
+			dirs = append(dirs, filepath.Join(siteDir, filepath.FromSlash(v.Path)))
+		}
+
+		if inventoryPages.Latest.Name != "" {
+			// the permalink of the latest version.
+			dirs = append(dirs, filepath.Join(siteDir, inventoryPages.Latest.Name))
+		}
+	}
+
+	for _, dir := range dirs {
+		err = os.WriteFile(filepath.Join(dir, fileName), page, 0o644)
+		if err != nil {
+			return fmt.Errorf("failed to write the 404 page: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// buildPages builds the inventory of the pages of the versions built in the site.
+// The pages of the latest version are read from its permalink.
+func buildPages(siteDir string, inventory menu.Inventory) pages {
+	var result pages
+
+	for _, v := range inventory.Versions {
+		if v.URL != "" || v.Hidden {
+			// not in the site, or not listed.
+			continue
+		}
+
+		p := v.Path
+		if p == "" {
+			p = v.Name
+		}
+
+		versionPageList, err := listPages(filepath.Join(siteDir, filepath.FromSlash(p)))
+		if err != nil {
+			log.Printf("[WARN] no pages for the version %s: %v", v.Name, err)
+			continue
+		}
+
+		if v.Path == "" {
+			// the latest version is served at the root of the site.
+			result.Latest = versionPages{Name: v.Name, Pages: versionPageList}
+			continue
+		}
+
+		result.Versions = append(result.Versions, versionPages{Name: v.Name, Path: p, Pages: versionPageList})
+	}
+
+	return result
+}
+
+// listPages lists the HTML pages of a directory: the index pages are referenced by their directory.
+func listPages(dir string) ([]string, error) {
+	list := []string{}
+
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() || filepath.Ext(p) != ".html" {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+
+		rel = filepath.ToSlash(rel)
+		if rel == fileName {
+			return nil
+		}
+
+		list = append(list, strings.TrimSuffix(rel, "index.html"))
+
+		return nil
+	})
+
+	return list, err
+}
+
+func buildPage(basePath string, inventory menu.Inventory, config *types.NotFound) ([]byte, error) {
+	model := pageModel{
+		Title:         defaultTitle,
+		Message:       defaultMessage,
+		BasePath:      basePath,
+		PagesFileName: PagesFileName,
+	}
+
+	if config.Title != "" {
+		model.Title = config.Title
+	}
+
+	if config.Message != "" {
+		model.Message = config.Message
+	}
+
+	for _, v := range inventory.Versions {
+		if v.Hidden {
+			continue
+		}
+
+		link := versionLink{Text: v.Text, URL: v.URL}
+		if link.URL == "" {
+			link.URL = basePath + strings.TrimPrefix(v.Path+"/", "/")
+		}
+
+		model.Versions = append(model.Versions, link)
+	}
+
+	tmpl, err := template.New(fileName).Parse(pageTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the 404 template: %w", err)
+	}
+
+	buf := &bytes.Buffer{}
+
+	err = tmpl.Execute(buf, model)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render the 404 page: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,142 @@
+package notfound
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/structor/menu"
+	"github.com/traefik/structor/types"
+)
+
+func TestBuild(t *testing.T) {
+	siteDir, err := os.MkdirTemp("", "structor-test")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(siteDir) }()
+
+	for _, file := range []string{
+		"index.html",
+		"404.html",
+		filepath.Join("routing", "overview", "index.html"),
+		filepath.Join("v2.9", "index.html"),
+		filepath.Join("v2.9", "404.html"),
+		filepath.Join("v2.9", "routing", "overview", "index.html"),
+		filepath.Join("v2.8", "index.html"),
+		filepath.Join("v2.8", "basics", "index.html"),
+		filepath.Join("v2.8", "sitemap.xml"),
+		filepath.Join("master", "index.html"),
+		filepath.Join("v2.7", "index.html"),
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(siteDir, file)), os.ModePerm))
+		require.NoError(t, os.WriteFile(filepath.Join(siteDir, file), []byte("<html></html>"), 0o644))
+	}
+
+	inventory := menu.Inventory{
+		Latest:       "v2.9.6",
+		Experimental: "master",
+		Versions: []menu.InventoryVersion{
+			{Name: "master", Text: "Experimental", Path: "master"},
+			{Name: "v2.9", Text: "v2.9 Latest", Path: ""},
+			{Name: "v2.8", Text: "v2.8", Path: "v2.8"},
+			{Name: "v2.7", Text: "v2.7", Path: "v2.7", Hidden: true},
+			{Name: "v1.7", Text: "v1.7", URL: "https://v1.doc.traefik.io/traefik/"},
+		},
+	}
+
+	err = Build(siteDir, "/traefik/", inventory, &types.NotFound{PerVersion: true})
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(siteDir, PagesFileName))
+	require.NoError(t, err)
+
+	var inventoryPages pages
+	require.NoError(t, json.Unmarshal(content, &inventoryPages))
+
+	expected := pages{
+		Latest: versionPages{Name: "v2.9", Pages: []string{"", "routing/overview/"}},
+		Versions: []versionPages{
+			{Name: "master", Path: "master", Pages: []string{""}},
+			{Name: "v2.8", Path: "v2.8", Pages: []string{"basics/", ""}},
+		},
+	}
+	assert.Equal(t, expected, inventoryPages)
+
+	page, err := os.ReadFile(filepath.Join(siteDir, "404.html"))
+	require.NoError(t, err)
+
+	assert.Contains(t, string(page), `var basePath = "/traefik/";`)
+	assert.Contains(t, string(page), `<li><a href="/traefik/master/">Experimental</a></li>`)
+	assert.Contains(t, string(page), `<li><a href="/traefik/">v2.9 Latest</a></li>`)
+	assert.Contains(t, string(page), `<li><a href="https://v1.doc.traefik.io/traefik/">v1.7</a></li>`)
+	assert.NotContains(t, string(page), "v2.7")
+
+	for _, dir := range []string{"master", "v2.8", "v2.9"} {
+		content, err := os.ReadFile(filepath.Join(siteDir, dir, "404.html"))
+		require.NoError(t, err)
+
+		assert.Equal(t, string(page), string(content), dir)
+	}
+}
+
+func Test_redirect(t *testing.T) {
+	node, err := exec.LookPath("node")
+	if err != nil {
+		t.Skip("node is required")
+	}
+
+	page, err := buildPage("/traefik/", menu.Inventory{Latest: "v2.9.6"}, &types.NotFound{})
+	require.NoError(t, err)
+
+	dir, err := os.MkdirTemp("", "structor-test")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	pageFile := filepath.Join(dir, "404.html")
+	require.NoError(t, os.WriteFile(pageFile, page, 0o644))
+
+	testCases := []struct {
+		desc     string
+		path     string
+		expected string
+	}{
+		{
+			desc:     "nearest more recent version",
+			path:     "/traefik/v2.7/routing/overview/",
+			expected: "/traefik/v2.8/routing/overview/",
+		},
+		{
+			desc:     "latest version before the pre-release",
+			path:     "/traefik/v2.8/routing/overview/",
+			expected: "/traefik/routing/overview/",
+		},
+		{
+			desc:     "pre-release after the latest version",
+			path:     "/traefik/v2.8/plugins/",
+			expected: "/traefik/v3.0-rc/plugins/",
+		},
+		{
+			desc:     "index page",
+			path:     "/traefik/v2.7/basics/index.html",
+			expected: "/traefik/v2.8/basics/",
+		},
+		{
+			desc:     "unknown page",
+			path:     "/traefik/v2.8/unknown/",
+			expected: "",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			output, err := exec.Command(node, filepath.Join("fixtures", "redirect.js"), pageFile, filepath.Join("fixtures", "pages.json"), test.path).CombinedOutput()
+			require.NoError(t, err, string(output))
+
+			assert.Equal(t, test.expected, string(output))
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package redirect
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/traefik/structor/traefik"
+)
+
+// Formats of the server configurations.
+const (
+	FormatNetlify = "netlify"
+	FormatNginx   = "nginx"
+	FormatTraefik = "traefik"
+)
+
+// IsValidFormat checks if a server configuration format is supported.
+func IsValidFormat(format string) bool {
+	switch format {
+	case FormatNetlify, FormatNginx, FormatTraefik:
+		return true
+	default:
+		return false
+	}
+}
+
+// WriteConfiguration writes the server configuration of the redirections into a directory.
+// The paths of the redirections are prefixed by the base path of the site (ex: "/traefik/").
+// Returns the path of the file.
+func WriteConfiguration(format, dir, basePath string, redirections []Redirection) (string, error) {
+	var fileName string
+	var content []byte
+	var err error
+
+	switch format {
+	case FormatNetlify:
+		fileName, content = "_redirects", buildNetlify(basePath, redirections)
+	case FormatNginx:
+		fileName, content = "redirects.nginx.conf", buildNginx(basePath, redirections)
+	case FormatTraefik:
+		fileName = "redirects.traefik.yml"
+		content, err = buildTraefik(basePath, redirections)
+	default:
+		return "", fmt.Errorf("unsupported redirection format: %s", format)
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("failed to build the %s configuration: %w", format, err)
+	}
+
+	err = os.MkdirAll(dir, os.ModePerm)
+	if err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+
+	filename := filepath.Join(dir, fileName)
+
+	err = os.WriteFile(filename, content, 0o644)
+	if err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", filename, err)
+	}
+
+	return filename, nil
+}
+
+// buildNetlify builds the Netlify "_redirects" file.
+func buildNetlify(basePath string, redirections []Redirection) []byte {
+	buf := &bytes.Buffer{}
+	for _, r := range redirections {
+		_, _ = fmt.Fprintf(buf, "%s %s 301\n", toAbsolutePath(basePath, r.From), toAbsolutePath(basePath, r.To))
+	}
+
+	return buf.Bytes()
+}
+
+// buildNginx builds a nginx "map" of the redirections.
+func buildNginx(basePath string, redirections []Redirection) []byte {
+	buf := &bytes.Buffer{}
+	buf.WriteString("# Generated by structor: include this file in the http block, and add to the server block:\n")
+	buf.WriteString("#   if ($structor_redirect) { return 301 $structor_redirect; }\n")
+	buf.WriteString("map $uri $structor_redirect {\n")
+	buf.WriteString("    default \"\";\n")
+
+	for _, r := range redirections {
+		_, _ = fmt.Fprintf(buf, "    %s %s;\n", toAbsolutePath(basePath, r.From), toAbsolutePath(basePath, r.To))
+	}
+
+	buf.WriteString("}\n")
+
+	return buf.Bytes()
+}
+
+// MiddlewaresChainName the name of the Traefik middleware chaining all the redirections.
+const MiddlewaresChainName = "structor-redirects"
+
+// buildTraefik builds a Traefik dynamic configuration of the redirection middlewares.
+func buildTraefik(basePath string, redirections []Redirection) ([]byte, error) {
+	config := traefik.Configuration{
+		HTTP: traefik.HTTP{Middlewares: Middlewares(basePath, redirections)},
+	}
+
+	return config.Marshal(traefik.FormatYAML)
+}
+
+// Middlewares builds the Traefik middlewares of the redirections:
+// a redirectRegex middleware by redirection, and a chain middleware ("structor-redirects") of all the redirections.
+// Without redirections, there are no middlewares.
+// The query string of a request is kept by its redirection.
+func Middlewares(basePath string, redirections []Redirection) map[string]traefik.Middleware {
+	middlewares := map[string]traefik.Middleware{}
+
+	if len(redirections) == 0 {
+		return middlewares
+	}
+
+	var names []string
+	for i, r := range redirections {
+		name := fmt.Sprintf("structor-redirect-%d", i)
+		names = append(names, name)
+
+		regex := "^(https?://[^/]+)" + regexp.QuoteMeta(toAbsolutePath(basePath, r.From))
+		if strings.HasSuffix(r.From, "/") {
+			regex += `(?:index\.html)?`
+		}
+
+		middlewares[name] = traefik.Middleware{
+			RedirectRegex: &traefik.RedirectRegex{
+				Regex:       regex + `(\?.*)?$`,
+				Replacement: "${1}" + toAbsolutePath(basePath, r.To) + "${2}",
+				Permanent:   true,
+			},
+		}
+	}
+
+	middlewares[MiddlewaresChainName] = traefik.Middleware{Chain: &traefik.Chain{Middlewares: names}}
+
+	return middlewares
+}
+
+// toAbsolutePath converts a path relative to the root of the site to an absolute path (ex: "/traefik/v2.4/basics/").
+func toAbsolutePath(basePath, p string) string {
+	return "/" + strings.TrimPrefix(strings.Trim(basePath, "/")+"/", "/") + strings.TrimPrefix(p, "/")
+}
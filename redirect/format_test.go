@@ -0,0 +1,156 @@
+package redirect
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteConfiguration(t *testing.T) {
+	redirections := []Redirection{
+		{From: "basics/", To: "routing/overview/"},
+		{From: "v2.4/old.html", To: "v2.4/new/"},
+	}
+
+	testCases := []struct {
+		format   string
+		basePath string
+		expected string
+	}{
+		{
+			format:   FormatNetlify,
+			basePath: "/",
+			expected: `/basics/ /routing/overview/ 301
+/v2.4/old.html /v2.4/new/ 301
+`,
+		},
+		{
+			format:   FormatNginx,
+			basePath: "/traefik/",
+			expected: `# Generated by structor: include this file in the http block, and add to the server block:
+#   if ($structor_redirect) { return 301 $structor_redirect; }
+map $uri $structor_redirect {
+    default "";
+    /traefik/basics/ /traefik/routing/overview/;
+    /traefik/v2.4/old.html /traefik/v2.4/new/;
+}
+`,
+		},
+		{
+			format:   FormatTraefik,
+			basePath: "/traefik/",
+			expected: `http:
+    middlewares:
+        structor-redirect-0:
+            redirectRegex:
+                regex: ^(https?://[^/]+)/traefik/basics/(?:index\.html)?(\?.*)?$
+                replacement: ${1}/traefik/routing/overview/${2}
+                permanent: true
+        structor-redirect-1:
+            redirectRegex:
+                regex: ^(https?://[^/]+)/traefik/v2\.4/old\.html(\?.*)?$
+                replacement: ${1}/traefik/v2.4/new/${2}
+                permanent: true
+        structor-redirects:
+            chain:
+                middlewares:
+                    - structor-redirect-0
+                    - structor-redirect-1
+`,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.format, func(t *testing.T) {
+			t.Parallel()
+
+			dir, err := os.MkdirTemp("", "structor-test")
+			require.NoError(t, err)
+			defer func() { _ = os.RemoveAll(dir) }()
+
+			filename, err := WriteConfiguration(test.format, dir, test.basePath, redirections)
+			require.NoError(t, err)
+
+			content, err := os.ReadFile(filename)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, string(content))
+		})
+	}
+}
+
+func TestWriteConfiguration_unsupported(t *testing.T) {
+	_, err := WriteConfiguration("apache", filepath.Join(os.TempDir(), "structor-test"), "/", nil)
+	assert.Error(t, err)
+}
+
+func TestMiddlewares(t *testing.T) {
+	redirections := []Redirection{
+		{From: "basics/", To: "routing/overview/"},
+		{From: "v2.4/old.html", To: "v2.4/new/"},
+	}
+
+	middlewares := Middlewares("/traefik/", redirections)
+
+	testCases := []struct {
+		desc       string
+		middleware string
+		url        string
+		expected   string
+	}{
+		{
+			desc:       "directory",
+			middleware: "structor-redirect-0",
+			url:        "https://doc.traefik.io/traefik/basics/",
+			expected:   "https://doc.traefik.io/traefik/routing/overview/",
+		},
+		{
+			desc:       "index of a directory",
+			middleware: "structor-redirect-0",
+			url:        "https://doc.traefik.io/traefik/basics/index.html",
+			expected:   "https://doc.traefik.io/traefik/routing/overview/",
+		},
+		{
+			desc:       "query string",
+			middleware: "structor-redirect-0",
+			url:        "https://doc.traefik.io/traefik/basics/?q=router",
+			expected:   "https://doc.traefik.io/traefik/routing/overview/?q=router",
+		},
+		{
+			desc:       "file with a query string",
+			middleware: "structor-redirect-1",
+			url:        "https://doc.traefik.io/traefik/v2.4/old.html?q=router",
+			expected:   "https://doc.traefik.io/traefik/v2.4/new/?q=router",
+		},
+		{
+			desc:       "other page",
+			middleware: "structor-redirect-1",
+			url:        "https://doc.traefik.io/traefik/v2.4/old.html.bak",
+			expected:   "https://doc.traefik.io/traefik/v2.4/old.html.bak",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			redirectRegex := middlewares[test.middleware].RedirectRegex
+			require.NotNil(t, redirectRegex)
+
+			// the replacement of the middleware of Traefik.
+			actual := regexp.MustCompile(redirectRegex.Regex).ReplaceAllString(test.url, redirectRegex.Replacement)
+
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestMiddlewares_withoutRedirections(t *testing.T) {
+	assert.Empty(t, Middlewares("/", nil))
+}
@@ -0,0 +1,204 @@
+package redirect
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// stubMarker identifies the redirection pages generated by structor.
+const stubMarker = `content="structor-redirect"`
+
+const stubTemplate = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="generator" content="structor-redirect">
+<title>Redirecting...</title>
+<link rel="canonical" href="{{ . }}">
+<meta name="robots" content="noindex">
+<meta http-equiv="refresh" content="0; url={{ . }}">
+</head>
+<body>
+<p>This page has moved to <a href="{{ . }}">{{ . }}</a>.</p>
+</body>
+</html>
+`
+
+// Redirection a redirection from a page to another page, the paths are relative to the root of the site (ex: "v2.4/basics/").
+type Redirection struct {
+	From string
+	To   string
+}
+
+// WriteStub writes an HTML page redirecting to the target URL.
+func WriteStub(filename, target string) error {
+	tmpl, err := template.New("stub").Parse(stubTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to parse the redirection template: %w", err)
+	}
+
+	err = os.MkdirAll(filepath.Dir(filename), os.ModePerm)
+	if err != nil {
+		return fmt.Errorf("failed to create the directory of %s: %w", filename, err)
+	}
+
+	f, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", filename, err)
+	}
+
+	defer func() { _ = f.Close() }()
+
+	err = tmpl.Execute(f, target)
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", filename, err)
+	}
+
+	return nil
+}
+
+// ListPages lists the HTML pages of a directory (paths relative to the directory), a missing directory has no pages.
+func ListPages(dir string) ([]string, error) {
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	var pages []string
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() || filepath.Ext(p) != ".html" {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+
+		pages = append(pages, rel)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pages, nil
+}
+
+// WriteDirStubs writes into a directory a page redirecting to each HTML page of the target directory.
+// The other pages (paths relative to the directory, ex: the pages of a removed version) redirect to the root of the target directory.
+func WriteDirStubs(dir, targetDir string, pages []string) error {
+	for _, page := range pages {
+		if _, err := os.Stat(filepath.Join(targetDir, page)); err == nil {
+			// redirected to the same page by the walk of the target directory.
+			continue
+		}
+
+		stub := filepath.Join(dir, page)
+
+		target, err := filepath.Rel(filepath.Dir(stub), filepath.Join(targetDir, "index.html"))
+		if err != nil {
+			return err
+		}
+
+		err = WriteStub(stub, toURL(target))
+		if err != nil {
+			return err
+		}
+	}
+
+	return filepath.WalkDir(targetDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() || filepath.Ext(p) != ".html" {
+			return nil
+		}
+
+		rel, err := filepath.Rel(targetDir, p)
+		if err != nil {
+			return err
+		}
+
+		stub := filepath.Join(dir, rel)
+
+		target, err := filepath.Rel(filepath.Dir(stub), p)
+		if err != nil {
+			return err
+		}
+
+		return WriteStub(stub, toURL(target))
+	})
+}
+
+// WritePages writes the redirection pages into the site.
+// A page of the site is never replaced by a redirection, and a redirection to a missing page is ignored.
+func WritePages(siteDir string, redirections []Redirection) error {
+	for _, r := range redirections {
+		stub := toFilePath(siteDir, r.From)
+		if !isReplaceable(stub) {
+			log.Printf("[WARN] the page %s exists: no redirection to %s.", r.From, r.To)
+			continue
+		}
+
+		targetFile := toFilePath(siteDir, r.To)
+		if _, err := os.Stat(targetFile); err != nil {
+			log.Printf("[WARN] the page %s doesn't exist: no redirection from %s.", r.To, r.From)
+			continue
+		}
+
+		target, err := filepath.Rel(filepath.Dir(stub), targetFile)
+		if err != nil {
+			return err
+		}
+
+		err = WriteStub(stub, toURL(target))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isReplaceable checks if a file doesn't exist, or is a redirection page.
+func isReplaceable(filename string) bool {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return os.IsNotExist(err)
+	}
+
+	return bytes.Contains(content, []byte(stubMarker))
+}
+
+// toFilePath converts the path of a page to the path of its file: the directories are pages with an index.
+func toFilePath(siteDir, p string) string {
+	filename := filepath.Join(siteDir, filepath.FromSlash(p))
+	if strings.HasSuffix(p, "/") || path.Ext(p) == "" {
+		return filepath.Join(filename, "index.html")
+	}
+
+	return filename
+}
+
+// toURL converts a relative file path to a URL: the index pages are referenced by their directory.
+func toURL(p string) string {
+	u := filepath.ToSlash(p)
+	if path.Base(u) == "index.html" {
+		return strings.TrimSuffix(u, "index.html")
+	}
+
+	return u
+}
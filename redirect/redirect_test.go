@@ -0,0 +1,110 @@
+package redirect
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteDirStubs(t *testing.T) {
+	siteDir, err := os.MkdirTemp("", "structor-test")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(siteDir) }()
+
+	targetDir := filepath.Join(siteDir, "v2.0")
+	require.NoError(t, os.MkdirAll(filepath.Join(targetDir, "routing", "overview"), os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(targetDir, "index.html"), []byte("<html></html>"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(targetDir, "404.html"), []byte("<html></html>"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(targetDir, "routing", "overview", "index.html"), []byte("<html></html>"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(targetDir, "sitemap.xml"), []byte("<urlset></urlset>"), 0o644))
+
+	// the pages of the removed version.
+	pages := []string{"index.html", filepath.Join("routing", "overview", "index.html"), filepath.Join("middlewares", "removed", "index.html")}
+
+	err = WriteDirStubs(filepath.Join(siteDir, "v1.9"), targetDir, pages)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		file     string
+		expected string
+	}{
+		{file: "index.html", expected: `url=../v2.0/"`},
+		{file: "404.html", expected: `url=../v2.0/404.html"`},
+		{file: filepath.Join("routing", "overview", "index.html"), expected: `url=../../../v2.0/routing/overview/"`},
+		{file: filepath.Join("middlewares", "removed", "index.html"), expected: `url=../../../v2.0/"`},
+	}
+
+	for _, test := range testCases {
+		content, err := os.ReadFile(filepath.Join(siteDir, "v1.9", test.file))
+		require.NoError(t, err)
+
+		assert.Contains(t, string(content), test.expected, test.file)
+	}
+
+	assert.NoFileExists(t, filepath.Join(siteDir, "v1.9", "sitemap.xml"))
+}
+
+func TestListPages(t *testing.T) {
+	dir, err := os.MkdirTemp("", "structor-test")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "routing"), os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "index.html"), []byte("<html></html>"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "routing", "index.html"), []byte("<html></html>"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sitemap.xml"), []byte("<urlset></urlset>"), 0o644))
+
+	pages, err := ListPages(dir)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"index.html", filepath.Join("routing", "index.html")}, pages)
+
+	pages, err = ListPages(filepath.Join(dir, "missing"))
+	require.NoError(t, err)
+
+	assert.Empty(t, pages)
+}
+
+func TestWritePages(t *testing.T) {
+	siteDir, err := os.MkdirTemp("", "structor-test")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(siteDir) }()
+
+	require.NoError(t, os.MkdirAll(filepath.Join(siteDir, "v2.4", "routing", "overview"), os.ModePerm))
+	require.NoError(t, os.MkdirAll(filepath.Join(siteDir, "v2.4", "providers"), os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(siteDir, "v2.4", "routing", "overview", "index.html"), []byte("<html></html>"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(siteDir, "v2.4", "providers", "index.html"), []byte("<html></html>"), 0o644))
+
+	redirections := []Redirection{
+		{From: "v2.4/basics/", To: "v2.4/routing/overview/"},
+		{From: "v2.4/providers/", To: "v2.4/routing/overview/"},
+		{From: "v2.4/missing/", To: "v2.4/unknown/"},
+	}
+
+	err = WritePages(siteDir, redirections)
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(siteDir, "v2.4", "basics", "index.html"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), `url=../routing/overview/"`)
+
+	// a page of the site is never replaced.
+	content, err = os.ReadFile(filepath.Join(siteDir, "v2.4", "providers", "index.html"))
+	require.NoError(t, err)
+	assert.Equal(t, "<html></html>", string(content))
+
+	assert.NoDirExists(t, filepath.Join(siteDir, "v2.4", "missing"))
+
+	// a redirection page is replaced.
+	redirections[0].To = "v2.4/providers/"
+
+	err = WritePages(siteDir, redirections)
+	require.NoError(t, err)
+
+	content, err = os.ReadFile(filepath.Join(siteDir, "v2.4", "basics", "index.html"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), `url=../providers/"`)
+}
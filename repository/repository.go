@@ -3,12 +3,15 @@ package repository
 import (
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/ldez/go-git-cmd-wrapper/branch"
 	"github.com/ldez/go-git-cmd-wrapper/git"
 	gTypes "github.com/ldez/go-git-cmd-wrapper/types"
 	"github.com/ldez/go-git-cmd-wrapper/worktree"
+	"github.com/traefik/structor/types"
 )
 
 // CreateWorkTree create a worktree for a specific version.
@@ -39,6 +42,78 @@ func ListBranches(debug bool) ([]string, error) {
 	return branches, nil
 }
 
+// GetCommitInformation Gets the information of the commit of a reference.
+func GetCommitInformation(ref string, debug bool) (types.CommitInformation, error) {
+	output, err := git.Raw("log", commitFormat(ref), git.Debugger(debug))
+	if err != nil {
+		return types.CommitInformation{}, fmt.Errorf("failed to get commit of %s: %w", ref, err)
+	}
+
+	parts := strings.Fields(output)
+	if len(parts) != 2 {
+		return types.CommitInformation{}, fmt.Errorf("invalid commit information for %s: %q", ref, output)
+	}
+
+	date, err := time.Parse(time.RFC3339, parts[1])
+	if err != nil {
+		return types.CommitInformation{}, fmt.Errorf("failed to parse commit date of %s: %w", ref, err)
+	}
+
+	return types.CommitInformation{SHA: parts[0], Date: date}, nil
+}
+
+// HasCommitsSince checks if a reference has commits which are not reachable from another reference (ex: a branch with commits after a tag).
+func HasCommitsSince(ref, since string, debug bool) (bool, error) {
+	output, err := git.Raw("rev-list", revListCount(since+".."+ref), git.Debugger(debug))
+	if err != nil {
+		return false, fmt.Errorf("failed to get commits of %s since %s: %w", ref, since, err)
+	}
+
+	count, err := strconv.Atoi(strings.TrimSpace(output))
+	if err != nil {
+		return false, fmt.Errorf("invalid count of commits of %s since %s: %q", ref, since, output)
+	}
+
+	return count > 0, nil
+}
+
+// ListTags List all tags.
+func ListTags(debug bool) ([]string, error) {
+	output, err := git.Raw("tag", tagList, git.Debugger(debug))
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieves tags: %w", err)
+	}
+
+	var tags []string
+	for _, tagName := range strings.Split(output, "\n") {
+		trimmedName := strings.TrimSpace(tagName)
+		if trimmedName != "" {
+			tags = append(tags, trimmedName)
+		}
+	}
+
+	return tags, nil
+}
+
+func commitFormat(ref string) func(*gTypes.Cmd) {
+	return func(g *gTypes.Cmd) {
+		g.AddOptions("-1")
+		g.AddOptions("--format=%H %cI")
+		g.AddOptions(ref)
+	}
+}
+
+func revListCount(revisions string) func(*gTypes.Cmd) {
+	return func(g *gTypes.Cmd) {
+		g.AddOptions("--count")
+		g.AddOptions(revisions)
+	}
+}
+
+func tagList(g *gTypes.Cmd) {
+	g.AddOptions("--list")
+}
+
 func branchVersionPattern(g *gTypes.Cmd) {
 	g.AddOptions("origin\\/v*")
 }
@@ -5,6 +5,7 @@ import (
 	"log"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/ldez/go-git-cmd-wrapper/git"
 	"github.com/stretchr/testify/assert"
@@ -41,3 +42,75 @@ func TestListBranches_error(t *testing.T) {
 	_, err := ListBranches(true)
 	assert.EqualError(t, err, "failed to retrieves branches: fail")
 }
+
+func TestHasCommitsSince(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		output   string
+		expected bool
+	}{
+		{desc: "commits", output: "3\n", expected: true},
+		{desc: "no commits", output: "0\n", expected: false},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			git.CmdExecutor = func(name string, debug bool, args ...string) (string, error) {
+				if debug {
+					log.Println(name, strings.Join(args, " "))
+				}
+
+				assert.Equal(t, []string{"rev-list", "--count", "v1.3.1..origin/v1.3"}, args)
+
+				return test.output, nil
+			}
+
+			hasCommits, err := HasCommitsSince("origin/v1.3", "v1.3.1", true)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, hasCommits)
+		})
+	}
+}
+
+func TestGetCommitInformation(t *testing.T) {
+	git.CmdExecutor = func(name string, debug bool, args ...string) (string, error) {
+		if debug {
+			log.Println(name, strings.Join(args, " "))
+		}
+		return "a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4e5f6a1b2 2023-02-14T10:11:12+01:00\n", nil
+	}
+
+	info, err := GetCommitInformation("origin/v1.3", true)
+	require.NoError(t, err)
+
+	assert.Equal(t, "a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4e5f6a1b2", info.SHA)
+	assert.Equal(t, "2023-02-14T10:11:12+01:00", info.Date.Format(time.RFC3339))
+}
+
+func TestGetCommitInformation_error(t *testing.T) {
+	git.CmdExecutor = func(name string, debug bool, args ...string) (string, error) {
+		return "", errors.New("fail")
+	}
+
+	_, err := GetCommitInformation("origin/v1.3", true)
+	assert.EqualError(t, err, "failed to get commit of origin/v1.3: fail")
+}
+
+func TestListTags(t *testing.T) {
+	git.CmdExecutor = func(name string, debug bool, args ...string) (string, error) {
+		if debug {
+			log.Println(name, strings.Join(args, " "))
+		}
+		return `v1.1.0
+v1.2.0
+v1.2.1
+`, nil
+	}
+
+	tags, err := ListTags(true)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"v1.1.0", "v1.2.0", "v1.2.1"}, tags)
+}
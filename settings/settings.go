@@ -0,0 +1,97 @@
+package settings
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/traefik/structor/file"
+	"github.com/traefik/structor/types"
+	"gopkg.in/yaml.v3"
+)
+
+// Load Loads the settings from a configuration file (file path or URL).
+func Load(configPath string) (*types.Settings, error) {
+	if configPath == "" {
+		return &types.Settings{}, nil
+	}
+
+	content, err := getContent(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := &types.Settings{}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+
+	err = decoder.Decode(settings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the configuration file %s: %w", configPath, err)
+	}
+
+	err = validate(settings)
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration file %s: %w", configPath, err)
+	}
+
+	return settings, nil
+}
+
+func validate(settings *types.Settings) error {
+	for _, extra := range settings.GetExtra() {
+		if extra.Archive == "" {
+			continue
+		}
+
+		err := validateSitePath(extra.GetPath())
+		if err != nil {
+			return fmt.Errorf("extra version %s: %w", extra.Name, err)
+		}
+	}
+
+	for _, exp := range settings.GetExperimental() {
+		p := exp.GetPath()
+		if exp.Path != "" {
+			// a path made only of slashes is the root of the site.
+			p = strings.Trim(exp.Path, "/")
+		}
+
+		err := validateSitePath(p)
+		if err != nil {
+			return fmt.Errorf("experimental branch %s: %w", exp.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// validateSitePath checks that a path is a directory inside the site, other than the root of the site.
+func validateSitePath(p string) error {
+	cleaned := path.Clean(p)
+	if p == "" || cleaned == "." || !filepath.IsLocal(filepath.FromSlash(cleaned)) {
+		return fmt.Errorf("invalid path %q: the path must be a directory inside the site", p)
+	}
+
+	return nil
+}
+
+func getContent(configPath string) ([]byte, error) {
+	if _, errStat := os.Stat(configPath); errStat == nil {
+		content, err := os.ReadFile(configPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the configuration file: %w", err)
+		}
+		return content, nil
+	}
+
+	content, err := file.Download(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download the configuration file: %w", err)
+	}
+	return content, nil
+}
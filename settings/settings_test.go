@@ -0,0 +1,108 @@
+package settings
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/structor/types"
+)
+
+func TestLoad(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		configPath string
+		expected   *types.Settings
+	}{
+		{
+			desc:       "no configuration file",
+			configPath: "",
+			expected:   &types.Settings{},
+		},
+		{
+			desc:       "configuration file",
+			configPath: filepath.Join(".", "fixtures", "structor.yml"),
+			expected: &types.Settings{
+				Lifecycle: &types.Lifecycle{
+					KeepMinors: 2,
+					Rules: []types.LifecycleRule{
+						{Versions: "v2.11", State: "lts"},
+						{Versions: ">= 1.0, < 2.0", State: "supported", EOL: time.Date(2021, time.December, 31, 0, 0, 0, 0, time.UTC)},
+					},
+				},
+				NonSemver: &types.NonSemver{
+					State: "obsolete",
+					Sort:  "explicit",
+					Order: []string{"v2.x-legacy"},
+					Versions: []types.NonSemverVersion{
+						{Name: "v2.x-legacy", Label: "v2 (legacy)"},
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			settings, err := Load(test.configPath)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, settings)
+		})
+	}
+}
+
+func TestLoad_error(t *testing.T) {
+	_, err := Load(filepath.Join(".", "fixtures", "unknown-field.yml"))
+	require.Error(t, err)
+
+	_, err = Load(filepath.Join(".", "fixtures", "missing.yml"))
+	require.Error(t, err)
+
+	_, err = Load(filepath.Join(".", "fixtures", "invalid-archive-path.yml"))
+	require.Error(t, err)
+
+	_, err = Load(filepath.Join(".", "fixtures", "invalid-experimental-path.yml"))
+	require.Error(t, err)
+
+	_, err = Load(filepath.Join(".", "fixtures", "root-experimental-path.yml"))
+	require.Error(t, err)
+}
+
+func Test_validateSitePath(t *testing.T) {
+	testCases := []struct {
+		path     string
+		expected bool
+	}{
+		{path: "v1.7", expected: true},
+		{path: "archives/v1.7", expected: true},
+		{path: "archives/../v1.7", expected: true},
+		{path: "", expected: false},
+		{path: ".", expected: false},
+		{path: "./", expected: false},
+		{path: "/", expected: false},
+		{path: "/v1.7", expected: false},
+		{path: "..", expected: false},
+		{path: "../v1.7", expected: false},
+		{path: "v1.7/../..", expected: false},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.path, func(t *testing.T) {
+			t.Parallel()
+
+			err := validateSitePath(test.path)
+			if test.expected {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
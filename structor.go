@@ -1,13 +1,19 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/cobra/doc"
 	"github.com/traefik/structor/core"
+	"github.com/traefik/structor/menu"
+	"github.com/traefik/structor/redirect"
+	"github.com/traefik/structor/settings"
+	"github.com/traefik/structor/traefik"
 	"github.com/traefik/structor/types"
 )
 
@@ -17,12 +23,7 @@ const (
 )
 
 func main() {
-	cfg := &types.Configuration{
-		DockerImageName: defaultDockerImageName,
-		DockerfileName:  defaultDockerfileName,
-		NoCache:         false,
-		Menu:            &types.MenuFiles{},
-	}
+	cfg := newConfiguration()
 
 	rootCmd := &cobra.Command{
 		Use:     "structor",
@@ -30,23 +31,68 @@ func main() {
 		Long:    `Messor Structor: Manage multiple documentation versions with Mkdocs.`,
 		Version: version,
 		PreRunE: func(_ *cobra.Command, _ []string) error {
-			if cfg.Debug {
-				log.Printf("Run Structor command with config : %+v", cfg)
-			}
-
-			if cfg.DockerImageName == "" {
-				log.Printf("'image-name' is undefined, fallback to %s.", defaultDockerImageName)
-				cfg.DockerImageName = defaultDockerImageName
-			}
-
-			return validateConfig(cfg)
+			return prepareConfig(cfg)
 		},
 		RunE: func(_ *cobra.Command, _ []string) error {
 			return core.Execute(cfg)
 		},
 	}
 
+	addBuildFlags(rootCmd, cfg)
+
 	flags := rootCmd.Flags()
+	flags.StringSliceVar(&cfg.Only, "only", nil, "Build only the versions matching these rules (version name, glob, /regular expression/, or semver constraint), the other versions are taken from the base site.")
+	flags.StringVar(&cfg.Since, "since", "", "Build only the versions with commits which are not in this git reference (ex: a release tag), the other versions are taken from the base site.")
+	flags.StringVar(&cfg.BaseSite, "base-site", "", "Directory or archive (tar.gz or zip, file path or URL) of a previous output, used for the versions which are not built (default: the current 'site' directory).")
+
+	docCmd := &cobra.Command{
+		Use:    "doc",
+		Short:  "Generate documentation",
+		Hidden: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			err := doc.GenMarkdownTree(rootCmd, "./docs")
+			if err != nil {
+				return err
+			}
+
+			return writeMenuReference(filepath.Join(".", "docs", "menu-templates.md"))
+		},
+	}
+
+	rootCmd.AddCommand(docCmd)
+
+	rootCmd.AddCommand(newMenuCmd())
+
+	rootCmd.AddCommand(newPreviewCmd())
+
+	versionCmd := &cobra.Command{
+		Use:   "version",
+		Short: "Display version",
+		Run: func(_ *cobra.Command, _ []string) {
+			displayVersion(rootCmd.Name())
+		},
+	}
+
+	rootCmd.AddCommand(versionCmd)
+
+	if err := rootCmd.Execute(); err != nil {
+		fmt.Printf("failed to execute: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func newConfiguration() *types.Configuration {
+	return &types.Configuration{
+		DockerImageName: defaultDockerImageName,
+		DockerfileName:  defaultDockerfileName,
+		NoCache:         false,
+		Menu:            &types.MenuFiles{},
+	}
+}
+
+// addBuildFlags adds the flags of the documentation build.
+func addBuildFlags(cmd *cobra.Command, cfg *types.Configuration) {
+	flags := cmd.Flags()
 	flags.StringVarP(&cfg.Owner, "owner", "o", "", "Repository owner. [required]")
 	flags.StringVarP(&cfg.RepositoryName, "repo-name", "r", "", "Repository name. [required]")
 
@@ -58,41 +104,147 @@ func main() {
 	flags.BoolVar(&cfg.NoCache, "no-cache", false, "Set to 'true' to disable the Docker build cache.")
 
 	flags.StringVar(&cfg.ExperimentalBranchName, "exp-branch", "", "Build a branch as experimental.")
-	flags.StringSliceVar(&cfg.ExcludedBranches, "exclude", nil, "Exclude branches from the documentation generation.")
+	flags.StringSliceVar(&cfg.IncludedBranches, "include", nil, "Build only the branches matching these rules (version name, glob, /regular expression/, or semver constraint).")
+	flags.StringSliceVar(&cfg.ExcludedBranches, "exclude", nil, "Exclude the branches matching these rules (version name, glob, /regular expression/, or semver constraint) from the documentation generation.")
 
 	flags.BoolVar(&cfg.ForceEditionURI, "force-edit-url", false, "Add a dedicated edition URL for each version.")
+	flags.StringVar(&cfg.ConfigFile, "config", "", "File path or URL of the configuration file (YAML).")
+
+	flags.StringVar(&cfg.SiteURL, "site-url", "", "Site URL: the URLs of the versions are absolute URLs (default: relative to the root of the site).")
+	flags.StringVar(&cfg.BasePath, "base-path", "", "Base path of the site: the URLs of the versions are absolute paths (default: relative to the root of the site).")
+
 	flags.StringVar(&cfg.RequirementsURL, "rqts-url", "", "Use this requirements.txt to merge with the current requirements.txt. Can be a file path.")
 
 	flags.StringVar(&cfg.Menu.JsURL, "menu.js-url", "", "URL of the template of the JS file use for the multi version menu.")
 	flags.StringVar(&cfg.Menu.JsFile, "menu.js-file", "", "File path of the template of the JS file use for the multi version menu.")
 	flags.StringVar(&cfg.Menu.CSSURL, "menu.css-url", "", "URL of the template of the CSS file use for the multi version menu.")
 	flags.StringVar(&cfg.Menu.CSSFile, "menu.css-file", "", "File path of the template of the CSS file use for the multi version menu.")
+	flags.StringSliceVar(&cfg.Menu.Assets, "menu.assets", nil, "File paths or URLs of additional templates of the multi version menu (JS, CSS, or other files).")
+	flags.StringSliceVar(&cfg.Menu.Overrides, "menu.overrides", nil, "Templates of theme override files, written into the custom_dir of the theme: '<path>=<file path or URL>' (ex: 'partials/footer.html=./footer.html.gotmpl').")
+	flags.StringVar(&cfg.Menu.Theme, "menu.theme", "", "Use the built-in templates of a theme for the multi version menu (material, readthedocs, mkdocs, bootstrap, auto).")
+	flags.StringVar(&cfg.Menu.Injection, "menu.injection", menu.InjectionManifest, "How the multi version menu is added to the documentation: 'manifest' (extra_javascript and extra_css) or 'html' (post-processing of the generated HTML files).")
+	flags.StringVar(&cfg.Menu.Static, "menu.static", "", "Add a static (JavaScript-free) version selector: 'nav' (Versions section of the nav) or 'partial' (theme partial in custom_dir).")
+}
 
-	docCmd := &cobra.Command{
-		Use:    "doc",
-		Short:  "Generate documentation",
-		Hidden: true,
-		RunE: func(cmd *cobra.Command, args []string) error {
-			return doc.GenMarkdownTree(rootCmd, "./docs")
+// prepareConfig validates the build configuration, and loads the configuration file.
+func prepareConfig(cfg *types.Configuration) error {
+	if cfg.Debug {
+		log.Printf("Run Structor command with config : %+v", cfg)
+	}
+
+	if cfg.DockerImageName == "" {
+		log.Printf("'image-name' is undefined, fallback to %s.", defaultDockerImageName)
+		cfg.DockerImageName = defaultDockerImageName
+	}
+
+	err := validateConfig(cfg)
+	if err != nil {
+		return err
+	}
+
+	cfg.Settings, err = settings.Load(cfg.ConfigFile)
+	if err != nil {
+		return err
+	}
+
+	return validateSettings(cfg.Settings)
+}
+
+func newPreviewCmd() *cobra.Command {
+	cfg := newConfiguration()
+
+	previewCfg := &types.PreviewConfiguration{}
+
+	previewCmd := &cobra.Command{
+		Use:   "preview",
+		Short: "Build a git reference (ex: a pull request) as a preview, into 'site/preview/<name>/'.",
+		Long: `Build a git reference (ex: a pull request) as a preview, into 'site/preview/<name>/'.
+The menu of the preview contains the versions of the site (site/versions.json, or the git branches).
+The other versions of the site are not modified.`,
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			err := validatePreviewConfig(previewCfg)
+			if err != nil {
+				return err
+			}
+
+			if previewCfg.Ref == "" {
+				// only the pruning of the previews.
+				return nil
+			}
+
+			return prepareConfig(cfg)
+		},
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return core.Preview(cfg, previewCfg)
 		},
 	}
 
-	rootCmd.AddCommand(docCmd)
+	addBuildFlags(previewCmd, cfg)
 
-	versionCmd := &cobra.Command{
-		Use:   "version",
-		Short: "Display version",
-		Run: func(_ *cobra.Command, _ []string) {
-			displayVersion(rootCmd.Name())
+	flags := previewCmd.Flags()
+	flags.StringVar(&previewCfg.Ref, "ref", "", "Git reference (commit SHA or branch) to build as a preview.")
+	flags.StringVar(&previewCfg.Name, "name", "", "Name of the preview (ex: 'pr-1234'): the preview is built into 'site/preview/<name>/'. [required with --ref]")
+	flags.BoolVar(&previewCfg.PrunePreviews, "prune-previews", false, "Remove the previews older than --prune-max-age, or not in --prune-keep.")
+	flags.DurationVar(&previewCfg.PruneMaxAge, "prune-max-age", 0, "Max age of the previews (ex: '720h'), with --prune-previews.")
+	flags.StringSliceVar(&previewCfg.PruneKeep, "prune-keep", nil, "Names of the previews to keep, with --prune-previews.")
+
+	return previewCmd
+}
+
+func newMenuCmd() *cobra.Command {
+	menuCmd := &cobra.Command{
+		Use:   "menu",
+		Short: "Tools for the menu templates.",
+	}
+
+	renderCfg := &types.RenderConfiguration{}
+
+	renderCmd := &cobra.Command{
+		Use:   "render",
+		Short: "Render a menu template without building the documentation.",
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			err := validateRenderConfig(renderCfg)
+			if err != nil {
+				return err
+			}
+
+			renderCfg.Settings, err = settings.Load(renderCfg.ConfigFile)
+			return err
+		},
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return menu.Render(renderCfg, os.Stdout)
 		},
 	}
 
-	rootCmd.AddCommand(versionCmd)
+	flags := renderCmd.Flags()
+	flags.StringVar(&renderCfg.TemplateFile, "template", "", "File path of the menu template. [required]")
+	flags.StringVarP(&renderCfg.Owner, "owner", "o", "", "Repository owner.")
+	flags.StringVarP(&renderCfg.RepositoryName, "repo-name", "r", "", "Repository name.")
+	flags.StringSliceVar(&renderCfg.Versions, "versions", nil, "Versions of the menu (default: the branches of the git repository).")
+	flags.StringVar(&renderCfg.Current, "current", "", "Current version. [required without --all]")
+	flags.StringVar(&renderCfg.Latest, "latest", "", "Latest release tag name. [required]")
+	flags.StringVar(&renderCfg.ExperimentalBranchName, "exp-branch", "", "Experimental branch name.")
+	flags.StringVar(&renderCfg.SiteURL, "site-url", "", "Site URL: the URLs of the versions are absolute URLs (default: relative to the root of the site).")
+	flags.StringVar(&renderCfg.BasePath, "base-path", "", "Base path of the site: the URLs of the versions are absolute paths (default: relative to the root of the site).")
+	flags.StringVar(&renderCfg.Locale, "locale", "", "Locale of the theme (theme.locale or theme.language of the manifest).")
+	flags.BoolVar(&renderCfg.All, "all", false, "Render the template for all the versions.")
+	flags.StringVar(&renderCfg.Output, "output", "", "Output file (output directory with --all). Default: stdout.")
+	flags.BoolVar(&renderCfg.Debug, "debug", false, "Debug mode.")
+	flags.StringVar(&renderCfg.ConfigFile, "config", "", "File path or URL of the configuration file (YAML).")
 
-	if err := rootCmd.Execute(); err != nil {
-		fmt.Printf("failed to execute: %v\n", err)
-		os.Exit(1)
+	menuCmd.AddCommand(renderCmd)
+
+	return menuCmd
+}
+
+func writeMenuReference(filePath string) error {
+	f, err := os.Create(filePath)
+	if err != nil {
+		return err
 	}
+	defer func() { _ = f.Close() }()
+
+	return menu.WriteModelReference(f)
 }
 
 func validateConfig(config *types.Configuration) error {
@@ -104,7 +256,97 @@ func validateConfig(config *types.Configuration) error {
 	if err != nil {
 		return err
 	}
-	return required(config.RepositoryName, "repo-name")
+	err = required(config.RepositoryName, "repo-name")
+	if err != nil {
+		return err
+	}
+
+	if config.Menu.Theme != "" && !menu.IsValidTheme(config.Menu.Theme) {
+		return fmt.Errorf("invalid menu theme: %s", config.Menu.Theme)
+	}
+
+	if !menu.IsValidInjection(config.Menu.Injection) {
+		return fmt.Errorf("invalid menu injection: %s", config.Menu.Injection)
+	}
+
+	if !menu.IsValidStatic(config.Menu.Static) {
+		return fmt.Errorf("invalid menu static selector: %s", config.Menu.Static)
+	}
+
+	if (config.Menu.Static != "" || len(config.Menu.Overrides) > 0) && config.Menu.Injection == menu.InjectionHTML {
+		return errors.New("the static selector and the theme overrides require the manifest injection")
+	}
+
+	return validatePartialBuild(config)
+}
+
+// validatePartialBuild checks that the menus of the versions taken from the base site can be regenerated (--only, --since).
+func validatePartialBuild(config *types.Configuration) error {
+	if len(config.Only) == 0 && config.Since == "" {
+		return nil
+	}
+
+	if config.Menu.Theme == menu.ThemeAuto {
+		return errors.New("the theme of the menu must be defined (not 'auto') to build a subset of the versions")
+	}
+
+	// the static menu and the theme overrides are rendered by MkDocs.
+	if config.Menu.Static != "" || len(config.Menu.Overrides) > 0 {
+		return errors.New("the static selector and the theme overrides can't be used to build a subset of the versions")
+	}
+
+	return nil
+}
+
+func validateSettings(s *types.Settings) error {
+	for _, format := range s.GetRedirects().GetFormats() {
+		if !redirect.IsValidFormat(format) {
+			return fmt.Errorf("invalid redirection format: %s", format)
+		}
+	}
+
+	if t := s.GetTraefik(); t != nil {
+		if !traefik.IsValidFormat(t.GetFormat()) {
+			return fmt.Errorf("invalid Traefik configuration format: %s", t.Format)
+		}
+
+		if t.Service == "" && t.URL == "" {
+			return errors.New("the service or the URL of the Traefik configuration is required")
+		}
+	}
+
+	return nil
+}
+
+func validatePreviewConfig(config *types.PreviewConfiguration) error {
+	if config.Ref == "" && !config.PrunePreviews {
+		return errors.New("--ref or --prune-previews is required")
+	}
+
+	if config.Ref != "" && !core.IsValidPreviewName(config.Name) {
+		return fmt.Errorf("invalid preview name: %q", config.Name)
+	}
+
+	if config.PrunePreviews && config.PruneMaxAge <= 0 && len(config.PruneKeep) == 0 {
+		return errors.New("--prune-max-age or --prune-keep is required with --prune-previews")
+	}
+
+	return nil
+}
+
+func validateRenderConfig(config *types.RenderConfiguration) error {
+	err := required(config.TemplateFile, "template")
+	if err != nil {
+		return err
+	}
+	err = required(config.Latest, "latest")
+	if err != nil {
+		return err
+	}
+	if !config.All {
+		return required(config.Current, "current")
+	}
+	return nil
 }
 
 func required(field, fieldName string) error {
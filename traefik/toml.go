@@ -0,0 +1,174 @@
+package traefik
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// marshalTOML encodes the configuration in TOML: the configuration is converted to a tree of maps through YAML.
+func marshalTOML(c Configuration) ([]byte, error) {
+	content, err := yaml.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+
+	var tree map[string]interface{}
+	err = yaml.Unmarshal(content, &tree)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := &bytes.Buffer{}
+	err = writeTable(buf, nil, tree)
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimPrefix(buf.Bytes(), []byte("\n")), nil
+}
+
+// writeTable writes the values of a table, then its sub-tables.
+func writeTable(buf *bytes.Buffer, keys []string, table map[string]interface{}) error {
+	var names, tables []string
+	for name, value := range table {
+		if _, ok := value.(map[string]interface{}); ok {
+			tables = append(tables, name)
+		} else {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+	sort.Strings(tables)
+
+	if len(names) > 0 && len(keys) > 0 {
+		_, _ = fmt.Fprintf(buf, "\n[%s]\n", joinKeys(keys))
+	}
+
+	for _, name := range names {
+		value, err := formatValue(table[name])
+		if err != nil {
+			return fmt.Errorf("%s: %w", joinKeys(append(keys, name)), err)
+		}
+
+		_, _ = fmt.Fprintf(buf, "  %s = %s\n", formatKey(name), value)
+	}
+
+	for _, name := range tables {
+		err := writeTable(buf, append(append([]string{}, keys...), name), table[name].(map[string]interface{}))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func formatValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return quote(v), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case []interface{}:
+		var items []string
+		for _, item := range v {
+			s, err := formatValue(item)
+			if err != nil {
+				return "", err
+			}
+
+			items = append(items, s)
+		}
+
+		return "[" + strings.Join(items, ", ") + "]", nil
+	case map[string]interface{}:
+		var names []string
+		for name := range v {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		var items []string
+		for _, name := range names {
+			s, err := formatValue(v[name])
+			if err != nil {
+				return "", err
+			}
+
+			items = append(items, formatKey(name)+" = "+s)
+		}
+
+		return "{ " + strings.Join(items, ", ") + " }", nil
+	default:
+		return "", fmt.Errorf("unsupported value type %T", value)
+	}
+}
+
+func joinKeys(keys []string) string {
+	var parts []string
+	for _, key := range keys {
+		parts = append(parts, formatKey(key))
+	}
+
+	return strings.Join(parts, ".")
+}
+
+// formatKey quotes the keys which are not bare keys.
+func formatKey(key string) string {
+	if key == "" {
+		return quote(key)
+	}
+
+	for _, c := range key {
+		if (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') && (c < '0' || c > '9') && c != '-' && c != '_' {
+			return quote(key)
+		}
+	}
+
+	return key
+}
+
+// quote quotes a TOML basic string, with the escape sequences of TOML only (strconv.Quote writes sequences which are invalid in TOML, ex: "\x00").
+func quote(value string) string {
+	b := &strings.Builder{}
+	b.WriteByte('"')
+
+	for _, c := range value {
+		switch c {
+		case '\b':
+			b.WriteString(`\b`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		default:
+			if c < 0x20 || c == 0x7f {
+				_, _ = fmt.Fprintf(b, `\u%04X`, c)
+				continue
+			}
+
+			b.WriteRune(c)
+		}
+	}
+
+	b.WriteByte('"')
+
+	return b.String()
+}
@@ -0,0 +1,89 @@
+package traefik
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Formats of the dynamic configuration.
+const (
+	FormatYAML = "yaml"
+	FormatTOML = "toml"
+)
+
+// Configuration a Traefik dynamic configuration (file provider).
+type Configuration struct {
+	HTTP HTTP `yaml:"http"`
+}
+
+// HTTP the HTTP configuration.
+type HTTP struct {
+	Routers     map[string]Router     `yaml:"routers,omitempty"`
+	Middlewares map[string]Middleware `yaml:"middlewares,omitempty"`
+	Services    map[string]Service    `yaml:"services,omitempty"`
+}
+
+// Router a router.
+type Router struct {
+	EntryPoints []string `yaml:"entryPoints,omitempty"`
+	Rule        string   `yaml:"rule"`
+	Middlewares []string `yaml:"middlewares,omitempty"`
+	Service     string   `yaml:"service"`
+}
+
+// Middleware a middleware.
+type Middleware struct {
+	Chain         *Chain         `yaml:"chain,omitempty"`
+	RedirectRegex *RedirectRegex `yaml:"redirectRegex,omitempty"`
+	StripPrefix   *StripPrefix   `yaml:"stripPrefix,omitempty"`
+}
+
+// Chain a middleware chaining middlewares.
+type Chain struct {
+	Middlewares []string `yaml:"middlewares"`
+}
+
+// RedirectRegex a middleware redirecting the requests matching a regular expression.
+type RedirectRegex struct {
+	Regex       string `yaml:"regex"`
+	Replacement string `yaml:"replacement"`
+	Permanent   bool   `yaml:"permanent"`
+}
+
+// StripPrefix a middleware removing prefixes from the path of the requests.
+type StripPrefix struct {
+	Prefixes []string `yaml:"prefixes"`
+}
+
+// Service a service.
+type Service struct {
+	LoadBalancer *LoadBalancer `yaml:"loadBalancer,omitempty"`
+}
+
+// LoadBalancer a load-balancer service.
+type LoadBalancer struct {
+	Servers []Server `yaml:"servers"`
+}
+
+// Server a server of a load-balancer.
+type Server struct {
+	URL string `yaml:"url"`
+}
+
+// IsValidFormat checks if a dynamic configuration format is supported.
+func IsValidFormat(format string) bool {
+	return format == FormatYAML || format == FormatTOML
+}
+
+// Marshal encodes the configuration in a format (yaml or toml).
+func (c Configuration) Marshal(format string) ([]byte, error) {
+	switch format {
+	case FormatYAML:
+		return yaml.Marshal(c)
+	case FormatTOML:
+		return marshalTOML(c)
+	default:
+		return nil, fmt.Errorf("unsupported Traefik configuration format: %s", format)
+	}
+}
@@ -0,0 +1,129 @@
+package traefik
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfiguration_Marshal(t *testing.T) {
+	config := Configuration{
+		HTTP: HTTP{
+			Routers: map[string]Router{
+				"structor": {
+					EntryPoints: []string{"websecure"},
+					Rule:        "PathPrefix(`/traefik/`)",
+					Middlewares: []string{"structor-strip-prefix"},
+					Service:     "structor",
+				},
+			},
+			Middlewares: map[string]Middleware{
+				"structor-strip-prefix": {StripPrefix: &StripPrefix{Prefixes: []string{"/traefik"}}},
+				"structor-removed-v1.2": {RedirectRegex: &RedirectRegex{
+					Regex:       `^(https?://[^/]+)/traefik/v1\.2/(.*)$`,
+					Replacement: "${1}/traefik/v1.3/${2}",
+					Permanent:   true,
+				}},
+			},
+			Services: map[string]Service{
+				"structor": {LoadBalancer: &LoadBalancer{Servers: []Server{{URL: "http://docs:8080"}}}},
+			},
+		},
+	}
+
+	testCases := []struct {
+		format   string
+		expected string
+	}{
+		{
+			format: FormatYAML,
+			expected: `http:
+    routers:
+        structor:
+            entryPoints:
+                - websecure
+            rule: PathPrefix(` + "`/traefik/`" + `)
+            middlewares:
+                - structor-strip-prefix
+            service: structor
+    middlewares:
+        structor-removed-v1.2:
+            redirectRegex:
+                regex: ^(https?://[^/]+)/traefik/v1\.2/(.*)$
+                replacement: ${1}/traefik/v1.3/${2}
+                permanent: true
+        structor-strip-prefix:
+            stripPrefix:
+                prefixes:
+                    - /traefik
+    services:
+        structor:
+            loadBalancer:
+                servers:
+                    - url: http://docs:8080
+`,
+		},
+		{
+			format: FormatTOML,
+			expected: `[http.middlewares."structor-removed-v1.2".redirectRegex]
+  permanent = true
+  regex = "^(https?://[^/]+)/traefik/v1\\.2/(.*)$"
+  replacement = "${1}/traefik/v1.3/${2}"
+
+[http.middlewares.structor-strip-prefix.stripPrefix]
+  prefixes = ["/traefik"]
+
+[http.routers.structor]
+  entryPoints = ["websecure"]
+  middlewares = ["structor-strip-prefix"]
+  rule = "PathPrefix(` + "`/traefik/`" + `)"
+  service = "structor"
+
+[http.services.structor.loadBalancer]
+  servers = [{ url = "http://docs:8080" }]
+`,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.format, func(t *testing.T) {
+			t.Parallel()
+
+			content, err := config.Marshal(test.format)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, string(content))
+		})
+	}
+}
+
+func Test_quote(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		value    string
+		expected string
+	}{
+		{desc: "empty", value: "", expected: `""`},
+		{desc: "regex", value: `^(https?://[^/]+)/v1\.2/(.*)$`, expected: `"^(https?://[^/]+)/v1\\.2/(.*)$"`},
+		{desc: "quote", value: `Host("doc.traefik.io")`, expected: `"Host(\"doc.traefik.io\")"`},
+		{desc: "escape sequences", value: "\b\t\n\f\r", expected: `"\b\t\n\f\r"`},
+		{desc: "control characters", value: "\x00\a\x1b\x7f", expected: `"\u0000\u0007\u001B\u007F"`},
+		{desc: "unicode", value: "é✓", expected: `"é✓"`},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, quote(test.value))
+		})
+	}
+}
+
+func TestConfiguration_Marshal_unsupported(t *testing.T) {
+	_, err := Configuration{}.Marshal("json")
+	assert.Error(t, err)
+}
@@ -0,0 +1,355 @@
+package types
+
+import (
+	"strings"
+	"time"
+)
+
+// Settings the content of the configuration file.
+type Settings struct {
+	// Lifecycle the policy defining the states of the versions.
+	Lifecycle *Lifecycle `yaml:"lifecycle,omitempty"`
+	// NonSemver the settings of the versions which are not semver.
+	NonSemver *NonSemver `yaml:"nonSemver,omitempty"`
+	// Labels the display labels of the versions, by state and by version, and their translations.
+	Labels *Labels `yaml:"labels,omitempty"`
+	// Visibility the rules defining the visibility of the versions in the menu.
+	Visibility []VisibilityRule `yaml:"visibility,omitempty"`
+	// Extra the versions which are not built from a branch: external URLs, or pre-built archives.
+	Extra []ExtraVersion `yaml:"extra,omitempty"`
+	// Banner the banner displayed on the versions, by state.
+	Banner *Banner `yaml:"banner,omitempty"`
+	// Branches the rules selecting the branches to build.
+	Branches *BranchRules `yaml:"branches,omitempty"`
+	// Experimental the experimental branches, in addition to the experimental branch (--exp-branch).
+	Experimental []ExperimentalBranch `yaml:"experimental,omitempty"`
+	// Retention the policy removing the old versions from the site.
+	Retention *Retention `yaml:"retention,omitempty"`
+	// Redirects the redirections of the pages moved between versions.
+	Redirects *Redirects `yaml:"redirects,omitempty"`
+	// Traefik the Traefik dynamic configuration serving the site.
+	Traefik *Traefik `yaml:"traefik,omitempty"`
+	// NotFound the 404 page redirecting to the same page in another version.
+	NotFound *NotFound `yaml:"notFound,omitempty"`
+}
+
+// GetLifecycle gets the lifecycle policy.
+func (s *Settings) GetLifecycle() *Lifecycle {
+	if s == nil {
+		return nil
+	}
+	return s.Lifecycle
+}
+
+// Lifecycle the lifecycle policy of the versions.
+type Lifecycle struct {
+	// KeepMinors the number of supported minors per major, the other minors are obsolete (default: 1).
+	KeepMinors int `yaml:"keepMinors,omitempty"`
+	// Rules the lifecycle of specific versions: the first matching rule is applied.
+	Rules []LifecycleRule `yaml:"rules,omitempty"`
+}
+
+// LifecycleRule the lifecycle of a set of versions.
+type LifecycleRule struct {
+	// Versions a version name or a semver constraint (ex: "v2.11", ">= 2.0, < 2.5").
+	Versions string `yaml:"versions"`
+	// State the state of the versions: supported, lts, obsolete, or eol.
+	State string `yaml:"state,omitempty"`
+	// EOL the end of life date: from this date, the state of the versions is EOL.
+	EOL time.Time `yaml:"eol,omitempty"`
+}
+
+// GetLabels gets the labels of the versions.
+func (s *Settings) GetLabels() *Labels {
+	if s == nil {
+		return nil
+	}
+	return s.Labels
+}
+
+// GetVisibility gets the visibility rules of the versions.
+func (s *Settings) GetVisibility() []VisibilityRule {
+	if s == nil {
+		return nil
+	}
+	return s.Visibility
+}
+
+// GetExtra gets the versions which are not built from a branch.
+func (s *Settings) GetExtra() []ExtraVersion {
+	if s == nil {
+		return nil
+	}
+	return s.Extra
+}
+
+// GetBanner gets the banner settings.
+func (s *Settings) GetBanner() *Banner {
+	if s == nil {
+		return nil
+	}
+	return s.Banner
+}
+
+// GetNonSemver gets the settings of the non-semver versions.
+func (s *Settings) GetNonSemver() *NonSemver {
+	if s == nil {
+		return nil
+	}
+	return s.NonSemver
+}
+
+// NonSemver the settings of the versions which are not semver (ex: "v2.x-legacy", "next").
+type NonSemver struct {
+	// State the default state of the non-semver versions: supported, lts, obsolete, or eol.
+	State string `yaml:"state,omitempty"`
+	// Sort the sort of the non-semver versions: natural (default), date (the most recent commit first), or explicit.
+	Sort string `yaml:"sort,omitempty"`
+	// Order the order of the non-semver versions, used by the explicit sort: the versions not listed are sorted naturally after.
+	Order []string `yaml:"order,omitempty"`
+	// Versions the settings of specific non-semver versions.
+	Versions []NonSemverVersion `yaml:"versions,omitempty"`
+}
+
+// NonSemverVersion the settings of a non-semver version.
+type NonSemverVersion struct {
+	// Name the version name (branch name).
+	Name string `yaml:"name"`
+	// Label the display label of the version (default: the version name).
+	Label string `yaml:"label,omitempty"`
+	// State the state of the version: supported, lts, obsolete, or eol.
+	State string `yaml:"state,omitempty"`
+}
+
+// Labels the display labels of the versions.
+// A label is a Go template, the data is the version (ex: "{{ .Name }} LTS").
+type Labels struct {
+	// LabelSet the default labels.
+	LabelSet `yaml:",inline"`
+	// Translations the labels by locale (ex: "zh"), the missing labels fall back to the default labels.
+	Translations map[string]LabelSet `yaml:"translations,omitempty"`
+}
+
+// LabelSet a set of labels.
+type LabelSet struct {
+	// States the labels by state (ex: "EXPERIMENTAL", "LTS").
+	States map[string]string `yaml:"states,omitempty"`
+	// Versions the labels by version name (ex: "v3.0").
+	Versions map[string]string `yaml:"versions,omitempty"`
+}
+
+// VisibilityRule the visibility of a set of versions in the menu: the first matching rule is applied.
+// The versions are always built, and listed in their own menu.
+type VisibilityRule struct {
+	// Versions a version name or a semver constraint (ex: "v4.0", ">= 4.0").
+	Versions string `yaml:"versions"`
+	// Hidden the versions are not listed in the menu.
+	Hidden bool `yaml:"hidden,omitempty"`
+	// ListedOn the versions are only listed in the menu of those versions (names or semver constraints).
+	ListedOn []string `yaml:"listedOn,omitempty"`
+	// External the versions are listed as a link to this URL.
+	External string `yaml:"external,omitempty"`
+}
+
+// ExtraVersion a version of the menu which is not built from a branch: an external URL or a pre-built archive.
+type ExtraVersion struct {
+	// Name the version name (ex: "v1.7"), used to sort the version with the other versions.
+	Name string `yaml:"name"`
+	// Label the display label of the version (default: the version name).
+	Label string `yaml:"label,omitempty"`
+	// State the state of the version: supported, lts, obsolete, or eol (default: computed as a built version).
+	State string `yaml:"state,omitempty"`
+	// URL the external URL of the version.
+	URL string `yaml:"url,omitempty"`
+	// Archive the file path or the URL of a pre-built archive of the version (tar.gz or zip).
+	Archive string `yaml:"archive,omitempty"`
+	// Path the path of the archive inside the site (default: the version name).
+	Path string `yaml:"path,omitempty"`
+	// InjectMenu injects the current menu into the HTML files of the archive.
+	InjectMenu bool `yaml:"injectMenu,omitempty"`
+	// Theme the theme used to select the built-in menu templates injected into the archive, when the menu theme is "auto".
+	Theme string `yaml:"theme,omitempty"`
+}
+
+// GetPath gets the path of the archive inside the site.
+func (e ExtraVersion) GetPath() string {
+	if e.Path != "" {
+		return e.Path
+	}
+	return e.Name
+}
+
+// Banner the banner displayed on the pages of a version, depending on its state.
+type Banner struct {
+	// States the messages by state (ex: "OBSOLETE", "EXPERIMENTAL"), an empty message disables the banner.
+	// A message is a Go template of HTML, the data are the version (.Version), and the URL of the same page in the latest version (.LatestURL).
+	States map[string]string `yaml:"states,omitempty"`
+	// Style the CSS declarations of the banner.
+	Style string `yaml:"style,omitempty"`
+}
+
+// GetExperimental gets the experimental branches.
+func (s *Settings) GetExperimental() []ExperimentalBranch {
+	if s == nil {
+		return nil
+	}
+	return s.Experimental
+}
+
+// FindExperimental finds the settings of an experimental branch.
+func (s *Settings) FindExperimental(name string) (ExperimentalBranch, bool) {
+	for _, e := range s.GetExperimental() {
+		if e.Name == name {
+			return e, true
+		}
+	}
+
+	return ExperimentalBranch{}, false
+}
+
+// ExperimentalBranch an experimental branch (ex: "master", or the preview of a feature "feature/gateway-api").
+type ExperimentalBranch struct {
+	// Name the branch name.
+	Name string `yaml:"name"`
+	// Path the path of the version in the site (ex: "preview/gateway-api"), the branch name by default.
+	Path string `yaml:"path,omitempty"`
+	// Label the display label of the version.
+	Label string `yaml:"label,omitempty"`
+	// Hidden the version is only listed in its own menu.
+	Hidden bool `yaml:"hidden,omitempty"`
+}
+
+// GetPath gets the path of the version in the site.
+func (e ExperimentalBranch) GetPath() string {
+	if p := strings.Trim(e.Path, "/"); p != "" {
+		return p
+	}
+
+	return e.Name
+}
+
+// GetBranches gets the rules selecting the branches to build.
+func (s *Settings) GetBranches() *BranchRules {
+	if s == nil {
+		return nil
+	}
+	return s.Branches
+}
+
+// BranchRules the rules selecting the branches to build.
+// A rule is a version name, a glob (ex: "v2.0*"), a regular expression (ex: "/^v2\.\d+$/"), or a semver constraint (ex: "< v1.4").
+type BranchRules struct {
+	// Include when defined, only the branches matching one of the rules are built.
+	Include []string `yaml:"include,omitempty"`
+	// Exclude the branches matching one of the rules are not built.
+	Exclude []string `yaml:"exclude,omitempty"`
+	// KeepMinors when defined, only the most recent minors of each major are built.
+	KeepMinors int `yaml:"keepMinors,omitempty"`
+}
+
+// GetRetention gets the retention policy.
+func (s *Settings) GetRetention() *Retention {
+	if s == nil {
+		return nil
+	}
+	return s.Retention
+}
+
+// Retention the policy removing the old versions from the site.
+// The versions which are not semver are always kept.
+type Retention struct {
+	// Majors when defined, only the N most recent majors are kept.
+	Majors int `yaml:"majors,omitempty"`
+	// Minors when defined, only the M most recent minors of each major are kept.
+	Minors int `yaml:"minors,omitempty"`
+	// Pinned the versions always kept: version names, globs, regular expressions, or semver constraints.
+	Pinned []string `yaml:"pinned,omitempty"`
+	// Redirect replaces the pages of the removed versions with redirections to the nearest kept version.
+	Redirect bool `yaml:"redirect,omitempty"`
+}
+
+// GetRedirects gets the redirections of the pages moved between versions.
+func (s *Settings) GetRedirects() *Redirects {
+	if s == nil {
+		return nil
+	}
+	return s.Redirects
+}
+
+// Redirects the redirections of the pages moved between versions.
+type Redirects struct {
+	// Formats the formats of the server configurations generated in addition to the redirection pages: netlify, nginx, or traefik.
+	Formats []string `yaml:"formats,omitempty"`
+	// Output the directory of the server configurations. Default: the site directory.
+	Output string `yaml:"output,omitempty"`
+	// Rules the redirections.
+	Rules []RedirectRule `yaml:"rules,omitempty"`
+}
+
+// GetFormats gets the formats of the server configurations.
+func (r *Redirects) GetFormats() []string {
+	if r == nil {
+		return nil
+	}
+	return r.Formats
+}
+
+// RedirectRule a redirection of a page, in each version matching the rule.
+type RedirectRule struct {
+	// From the old path of the page, relative to the root of the version (ex: "basics/").
+	From string `yaml:"from"`
+	// To the new path of the page, relative to the root of the version (ex: "routing/overview/").
+	To string `yaml:"to"`
+	// Versions the versions where the page moved: a version name, a glob, a regular expression, or a semver constraint. Default: all the versions.
+	Versions string `yaml:"versions,omitempty"`
+}
+
+// GetTraefik gets the settings of the Traefik dynamic configuration.
+func (s *Settings) GetTraefik() *Traefik {
+	if s == nil {
+		return nil
+	}
+	return s.Traefik
+}
+
+// Traefik the Traefik dynamic configuration (file provider) serving the site.
+type Traefik struct {
+	// Format the format of the dynamic configuration: yaml (default), or toml.
+	Format string `yaml:"format,omitempty"`
+	// Output the directory of the dynamic configuration. Default: the current directory.
+	Output string `yaml:"output,omitempty"`
+	// EntryPoints the entry points of the routers.
+	EntryPoints []string `yaml:"entryPoints,omitempty"`
+	// Rule the rule matching the site, combined with the path prefix of the site (ex: "Host(`doc.traefik.io`)").
+	Rule string `yaml:"rule,omitempty"`
+	// Service the name of the Traefik service serving the site.
+	Service string `yaml:"service,omitempty"`
+	// URL the URL of the server serving the site: a "structor" service is created, instead of using the service.
+	URL string `yaml:"url,omitempty"`
+}
+
+// GetFormat gets the format of the dynamic configuration.
+func (t *Traefik) GetFormat() string {
+	if t.Format == "" {
+		return "yaml"
+	}
+	return t.Format
+}
+
+// GetNotFound gets the settings of the 404 page.
+func (s *Settings) GetNotFound() *NotFound {
+	if s == nil {
+		return nil
+	}
+	return s.NotFound
+}
+
+// NotFound the 404 page redirecting to the same page in the nearest more recent version, or in the latest version.
+type NotFound struct {
+	// Title the title of the page displayed when no version has the page.
+	Title string `yaml:"title,omitempty"`
+	// Message the message displayed before the list of the versions.
+	Message string `yaml:"message,omitempty"`
+	// PerVersion replaces the 404 page of each version, in addition to the 404 page at the root of the site.
+	PerVersion bool `yaml:"perVersion,omitempty"`
+}
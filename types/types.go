@@ -1,5 +1,7 @@
 package types
 
+import "time"
+
 // NoOption empty struct.
 type NoOption struct{}
 
@@ -11,20 +13,61 @@ type Configuration struct {
 	DockerfileURL          string     `short:"d" long:"dockerfile-url" description:"Use this Dockerfile when --dockerfile-name is not found. Can be a file path. [required]"`
 	DockerfileName         string     `long:"dockerfile-name" description:"Search and use this Dockerfile in the repository (in './docs/' or in './') for building documentation."`
 	ExperimentalBranchName string     `long:"exp-branch" description:"Build a branch as experimental."`
-	ExcludedBranches       []string   `long:"exclude" description:"Exclude branches from the documentation generation."`
+	IncludedBranches       []string   `long:"include" description:"Build only the branches matching these rules (version name, glob, /regular expression/, or semver constraint)."`
+	ExcludedBranches       []string   `long:"exclude" description:"Exclude the branches matching these rules (version name, glob, /regular expression/, or semver constraint) from the documentation generation."`
 	DockerImageName        string     `long:"image-name" description:"Docker image name."`
 	Menu                   *MenuFiles `long:"menu" description:"Menu templates files."`
 	RequirementsURL        string     `long:"rqts-url" description:"Use this requirements.txt to merge with the current requirements.txt. Can be a file path."`
 	NoCache                bool       `long:"no-cache" description:"Set to 'true' to disable the Docker build cache."`
 	ForceEditionURI        bool       `long:"force-edit-url" description:"Add a dedicated edition URL for each version."`
+	ConfigFile             string     `long:"config" description:"File path or URL of the configuration file (YAML)."`
+	SiteURL                string     `long:"site-url" description:"Site URL: the URLs of the versions are absolute URLs (default: relative to the root of the site)."`
+	BasePath               string     `long:"base-path" description:"Base path of the site: the URLs of the versions are absolute paths (default: relative to the root of the site)."`
+	Only                   []string   `long:"only" description:"Build only the versions matching these rules (version name, glob, /regular expression/, or semver constraint), the other versions are taken from the base site."`
+	Since                  string     `long:"since" description:"Build only the versions with commits which are not in this git reference (ex: a release tag), the other versions are taken from the base site."`
+	BaseSite               string     `long:"base-site" description:"Directory or archive (tar.gz or zip, file path or URL) of a previous output, used for the versions which are not built (default: the current 'site' directory)."`
+	Settings               *Settings  `description:"Settings loaded from the configuration file."`
+}
+
+// RenderConfiguration menu render command configuration.
+type RenderConfiguration struct {
+	TemplateFile           string    `long:"template" description:"File path of the menu template. [required]"`
+	Owner                  string    `short:"o" description:"Repository owner."`
+	RepositoryName         string    `short:"r" long:"repo-name" description:"Repository name."`
+	Versions               []string  `long:"versions" description:"Versions of the menu (default: the branches of the git repository)."`
+	Current                string    `long:"current" description:"Current version. [required without --all]"`
+	Latest                 string    `long:"latest" description:"Latest release tag name. [required]"`
+	ExperimentalBranchName string    `long:"exp-branch" description:"Experimental branch name."`
+	SiteURL                string    `long:"site-url" description:"Site URL: the URLs of the versions are absolute URLs (default: relative to the root of the site)."`
+	BasePath               string    `long:"base-path" description:"Base path of the site: the URLs of the versions are absolute paths (default: relative to the root of the site)."`
+	Locale                 string    `long:"locale" description:"Locale of the theme (theme.locale or theme.language of the manifest)."`
+	All                    bool      `long:"all" description:"Render the template for all the versions."`
+	Output                 string    `long:"output" description:"Output file (output directory with --all). Default: stdout."`
+	Debug                  bool      `long:"debug" description:"Debug mode."`
+	ConfigFile             string    `long:"config" description:"File path or URL of the configuration file (YAML)."`
+	Settings               *Settings `description:"Settings loaded from the configuration file."`
+}
+
+// PreviewConfiguration preview command configuration.
+type PreviewConfiguration struct {
+	Ref           string        `long:"ref" description:"Git reference (commit SHA or branch) to build as a preview."`
+	Name          string        `long:"name" description:"Name of the preview (ex: 'pr-1234'): the preview is built into 'site/preview/<name>/'. [required with --ref]"`
+	PrunePreviews bool          `long:"prune-previews" description:"Remove the previews older than --prune-max-age, or not in --prune-keep."`
+	PruneMaxAge   time.Duration `long:"prune-max-age" description:"Max age of the previews (ex: '720h'), with --prune-previews."`
+	PruneKeep     []string      `long:"prune-keep" description:"Names of the previews to keep, with --prune-previews."`
 }
 
 // MenuFiles menu template files references.
 type MenuFiles struct {
-	JsURL   string `long:"js-url" description:"URL of the template of the JS file use for the multi version menu."`
-	JsFile  string `long:"js-file" description:"File path of the template of the JS file use for the multi version menu."`
-	CSSURL  string `long:"css-url" description:"URL of the template of the CSS file use for the multi version menu."`
-	CSSFile string `long:"css-file" description:"File path of the template of the CSS file use for the multi version menu."`
+	JsURL     string   `long:"js-url" description:"URL of the template of the JS file use for the multi version menu."`
+	JsFile    string   `long:"js-file" description:"File path of the template of the JS file use for the multi version menu."`
+	CSSURL    string   `long:"css-url" description:"URL of the template of the CSS file use for the multi version menu."`
+	CSSFile   string   `long:"css-file" description:"File path of the template of the CSS file use for the multi version menu."`
+	Assets    []string `long:"assets" description:"File paths or URLs of additional templates of the multi version menu (JS, CSS, or other files)."`
+	Overrides []string `long:"overrides" description:"Templates of theme override files, written into the custom_dir of the theme: '<path>=<file path or URL>' (ex: 'partials/footer.html=./footer.html.gotmpl')."`
+	Theme     string   `long:"theme" description:"Use the built-in templates of a theme for the multi version menu (material, readthedocs, mkdocs, bootstrap, auto)."`
+	Injection string   `long:"injection" description:"How the multi version menu is added to the documentation: 'manifest' (extra_javascript and extra_css) or 'html' (post-processing of the generated HTML files)."`
+	Static    string   `long:"static" description:"Add a static (JavaScript-free) version selector: 'nav' (Versions section of the nav) or 'partial' (theme partial in custom_dir)."`
 }
 
 // HasJsFile has JS file.
@@ -39,8 +82,22 @@ func (m *MenuFiles) HasCSSFile() bool {
 
 // VersionsInformation versions information.
 type VersionsInformation struct {
+	Owner        string
+	Repository   string
 	Current      string
+	CurrentRef   string // the git reference of the current version, when it's not its branch (ex: the commit of a preview).
 	Latest       string
 	Experimental string
 	CurrentPath  string
+	Commits      map[string]CommitInformation
+	Tags         []string
+	SiteURL      string
+	BasePath     string
+	Settings     *Settings
+}
+
+// CommitInformation commit information.
+type CommitInformation struct {
+	SHA  string
+	Date time.Time
 }